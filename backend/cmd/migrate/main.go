@@ -0,0 +1,100 @@
+package main
+
+import (
+	"RemitlyTask/src/config"
+	"RemitlyTask/src/database"
+	"RemitlyTask/src/migrations"
+	"RemitlyTask/src/storage"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+)
+
+func main() {
+	configFlags := config.BindFlags(flag.CommandLine)
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] up | down [steps|all] | status\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	cfg, err := configFlags.Load()
+	if err != nil {
+		log.Fatal("Invalid configuration: ", err)
+	}
+
+	db, dialect, err := storage.Connect(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer database.Close(db)
+
+	migrator, err := migrations.NewMigrator(db, dialect)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	switch command := flag.Arg(0); command {
+	case "up":
+		applied, err := migrator.Up()
+		printMigrations("applied", applied)
+		if err != nil {
+			log.Fatal(err)
+		}
+	case "down":
+		steps, err := parseSteps(flag.Arg(1))
+		if err != nil {
+			log.Fatal(err)
+		}
+		reverted, err := migrator.Down(steps)
+		printMigrations("reverted", reverted)
+		if err != nil {
+			log.Fatal(err)
+		}
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, status := range statuses {
+			state := "pending"
+			if status.AppliedAt != nil {
+				state = "applied " + status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%04d_%s: %s\n", status.Version, status.Name, state)
+		}
+	default:
+		log.Fatalf("Unknown command %q, expected up, down or status", command)
+	}
+}
+
+// parseSteps reads the number of migrations to revert, one by default.
+func parseSteps(arg string) (int, error) {
+	switch arg {
+	case "":
+		return 1, nil
+	case "all":
+		return -1, nil
+	}
+	steps, err := strconv.Atoi(arg)
+	if err != nil || steps < 1 {
+		return 0, fmt.Errorf("steps must be a positive number or all, got %q", arg)
+	}
+	return steps, nil
+}
+
+func printMigrations(action string, done []migrations.Migration) {
+	for _, migration := range done {
+		fmt.Printf("%s %04d_%s\n", action, migration.Version, migration.Name)
+	}
+	if len(done) == 0 {
+		fmt.Println("nothing to do")
+	}
+}
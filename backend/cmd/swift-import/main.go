@@ -0,0 +1,107 @@
+package main
+
+import (
+	"RemitlyTask/src/config"
+	"RemitlyTask/src/importer"
+	"RemitlyTask/src/repositories"
+	"RemitlyTask/src/storage"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+func main() {
+	verbose := flag.Bool("v", false, "also list unchanged rows")
+	sync := flag.Bool("sync", false, "make the stored dataset match the file, removing codes missing from it")
+	dryRun := flag.Bool("dry-run", false, "with -sync, print the JSON change report without applying it")
+	configFlags := config.BindFlags(flag.CommandLine)
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [-v] [-sync [-dry-run]] [-config file] [file.csv]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	cfg, err := configFlags.Load()
+	if err != nil {
+		log.Fatal("Invalid configuration: ", err)
+	}
+	if cfg.Storage.Driver == config.StorageMemory {
+		log.Fatal("Nothing to import into: the memory storage is lost when the program exits")
+	}
+
+	path := "data/db/data.csv"
+	if flag.NArg() > 0 {
+		path = flag.Arg(0)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		log.Fatal("Error opening file: ", err)
+	}
+	defer file.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	ctx = repositories.WithActor(ctx, repositories.Actor{Name: "swift-import"})
+
+	repo, closeRepo, err := storage.Open(ctx, cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer closeRepo()
+
+	if *sync {
+		report, err := importer.NewImporter(repo).Sync(ctx, file, *dryRun)
+		if err != nil {
+			log.Fatal("Sync aborted: ", err)
+		}
+		printReport(report)
+		return
+	}
+
+	summary, err := importer.NewImporter(repo).Import(ctx, file)
+	printSummary(summary, *verbose)
+	if err != nil {
+		log.Fatal("Import aborted: ", err)
+	}
+}
+
+func printReport(report importer.ChangeReport) {
+	if report.DryRun {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			log.Fatal("Error writing change report: ", err)
+		}
+		return
+	}
+
+	for _, row := range report.Rejected {
+		fmt.Printf("line %d: %s %s: %s\n", row.Line, row.SwiftCode, row.Status, row.Reason)
+	}
+	fmt.Printf("added: %d, restored: %d, modified: %d, removed: %d, rejected: %d\n",
+		len(report.Added), len(report.Restored), len(report.Modified), len(report.Removed), len(report.Rejected))
+}
+
+func printSummary(summary importer.Summary, verbose bool) {
+	for _, row := range summary.Rows {
+		switch {
+		case row.Status == importer.StatusRejected:
+			fmt.Printf("line %d: %s %s: %s\n", row.Line, row.SwiftCode, row.Status, row.Reason)
+		case row.Status != importer.StatusUnchanged || verbose:
+			fmt.Printf("line %d: %s %s\n", row.Line, row.SwiftCode, row.Status)
+		}
+	}
+
+	fmt.Printf("inserted: %d, restored: %d, updated: %d, unchanged: %d, rejected: %d\n",
+		summary.Count(importer.StatusInserted),
+		summary.Count(importer.StatusRestored),
+		summary.Count(importer.StatusUpdated),
+		summary.Count(importer.StatusUnchanged),
+		summary.Count(importer.StatusRejected))
+}
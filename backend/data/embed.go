@@ -0,0 +1,9 @@
+// Package data holds the SWIFT directory shipped with the binary.
+package data
+
+import _ "embed"
+
+// DirectoryCSV is db/data.csv, served by the embedded storage.
+//
+//go:embed db/data.csv
+var DirectoryCSV []byte
@@ -1,23 +1,134 @@
 package main
 
 import (
-	"RemitlyTask/src/database"
+	"RemitlyTask/src/config"
 	"RemitlyTask/src/handlers"
+	"RemitlyTask/src/router"
+	"RemitlyTask/src/rpc"
+	"RemitlyTask/src/services"
+	"RemitlyTask/src/storage"
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
 )
 
 func main() {
+	flags := config.BindFlags(flag.CommandLine)
+	flag.Parse()
 
-	handler := handlers.NewSwiftCodeHandler(database.DB)
-	r := gin.Default()
+	if err := run(flags); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(flags *config.Flags) error {
+	cfg, err := flags.Load()
+	if err != nil {
+		return err
+	}
+
+	level, err := cfg.SlogLevel()
+	if err != nil {
+		return err
+	}
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level})))
+	if level > slog.LevelDebug {
+		gin.SetMode(gin.ReleaseMode)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	repo, closeRepo, err := storage.Open(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer closeRepo()
+
+	service := services.NewSwiftCodeService(repo)
+	if !cfg.Storage.ReadOnly() && cfg.Storage.PurgeInterval > 0 {
+		go services.RunPurger(ctx, service, time.Duration(cfg.Storage.PurgeInterval), time.Duration(cfg.Storage.DeletedRetention))
+	}
+	handler := handlers.NewSwiftCodeHandlerByService(service)
+	r := router.New(handler, router.Options{
+		ReadOnly:           cfg.Storage.ReadOnly(),
+		LegacyErrorMessage: cfg.Server.LegacyErrorMessage,
+		RequestTimeout:     time.Duration(cfg.Server.RequestTimeout),
+		TrustedProxies:     cfg.Server.TrustedProxies,
+	})
+
+	server := &http.Server{
+		Addr:         cfg.Server.Addr,
+		Handler:      r,
+		ReadTimeout:  time.Duration(cfg.Server.ReadTimeout),
+		WriteTimeout: time.Duration(cfg.Server.WriteTimeout),
+		IdleTimeout:  time.Duration(cfg.Server.IdleTimeout),
+	}
+
+	var grpcServer *grpc.Server
+	var grpcListener net.Listener
+	if cfg.Server.GRPCAddr != "" {
+		grpcListener, err = net.Listen("tcp", cfg.Server.GRPCAddr)
+		if err != nil {
+			return err
+		}
+		grpcServer = rpc.NewServer(service, rpc.Options{
+			RequestTimeout: time.Duration(cfg.Server.RequestTimeout),
+			TrustedProxies: cfg.Server.TrustedProxies,
+		})
+	}
+
+	serveErr := make(chan error, 2)
+	go func() {
+		slog.Info("Listening", "addr", cfg.Server.Addr)
+		serveErr <- server.ListenAndServe()
+	}()
+	if grpcServer != nil {
+		go func() {
+			slog.Info("gRPC listening", "addr", cfg.Server.GRPCAddr)
+			serveErr <- grpcServer.Serve(grpcListener)
+		}()
+	}
+
+	select {
+	case err := <-serveErr:
+		if !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.Server.ShutdownTimeout))
+	defer cancel()
+	err = server.Shutdown(shutdownCtx)
+	if grpcServer != nil {
+		stopGRPC(shutdownCtx, grpcServer)
+	}
+	return err
+}
 
-	vCodes := r.Group("v1/swift-codes")
-	{
-		vCodes.GET("/:swift-code", handler.GetCode)
-		vCodes.GET("/country/:ISO2", handler.GetCodesByCountry)
-		vCodes.POST("", handler.AddNewSwiftCode)
-		vCodes.DELETE("/:swift-code", handler.DeleteCode)
+// stopGRPC lets the running calls finish until ctx is done, then closes them.
+func stopGRPC(ctx context.Context, s *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		s.Stop()
 	}
-	r.Run(":8080")
 }
@@ -0,0 +1,300 @@
+// Package client calls the SWIFT code API over HTTP and decodes its responses
+// into the types of src/models.
+package client
+
+import (
+	"RemitlyTask/src/models"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultTimeout    = 30 * time.Second
+	DefaultMaxRetries = 2
+	DefaultBackoff    = 200 * time.Millisecond
+	DefaultMaxBackoff = 5 * time.Second
+)
+
+// Options configure a Client. Zero values select the defaults; a negative
+// MaxRetries disables retries. Timeout is ignored when HTTPClient is given.
+type Options struct {
+	HTTPClient *http.Client
+	Timeout    time.Duration
+	MaxRetries int
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// Client calls the API at a base URL such as http://localhost:8080. Idempotent
+// requests that fail with a 5xx status or don't reach the server are retried
+// with exponential backoff. Add is only retried when the server couldn't be
+// connected to, since a failed response doesn't tell whether the code was
+// stored.
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	maxRetries int
+	backoff    time.Duration
+	maxBackoff time.Duration
+}
+
+func New(baseURL string, opts Options) (*Client, error) {
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+	if base.Scheme == "" || base.Host == "" {
+		return nil, fmt.Errorf("base URL %q must be absolute", baseURL)
+	}
+
+	c := &Client{
+		baseURL:    base,
+		httpClient: opts.HTTPClient,
+		maxRetries: opts.MaxRetries,
+		backoff:    opts.Backoff,
+		maxBackoff: opts.MaxBackoff,
+	}
+	if c.httpClient == nil {
+		timeout := opts.Timeout
+		if timeout == 0 {
+			timeout = DefaultTimeout
+		}
+		c.httpClient = &http.Client{Timeout: timeout}
+	}
+	switch {
+	case c.maxRetries == 0:
+		c.maxRetries = DefaultMaxRetries
+	case c.maxRetries < 0:
+		c.maxRetries = 0
+	}
+	if c.backoff <= 0 {
+		c.backoff = DefaultBackoff
+	}
+	if c.maxBackoff <= 0 {
+		c.maxBackoff = DefaultMaxBackoff
+	}
+	return c, nil
+}
+
+// GetCode returns the details of a SWIFT code. Branches is only set for
+// headquarters.
+func (c *Client) GetCode(ctx context.Context, swiftCode string) (models.SwiftCodeDetails, error) {
+	var details models.SwiftCodeDetails
+	err := c.do(ctx, http.MethodGet, "/v1/swift-codes/"+url.PathEscape(swiftCode), nil, nil, &details, true)
+	return details, err
+}
+
+// CountryOptions select a page of the codes of a country. Cursor is the
+// NextCursor of the previous page, which must be requested with the same
+// SortBy and Descending.
+type CountryOptions struct {
+	Limit         int
+	Cursor        string
+	SortBy        string
+	Descending    bool
+	IsHeadquarter *bool
+	Town          string
+}
+
+func (o CountryOptions) values() url.Values {
+	query := url.Values{}
+	if o.Limit > 0 {
+		query.Set("limit", strconv.Itoa(o.Limit))
+	}
+	if o.Cursor != "" {
+		query.Set("cursor", o.Cursor)
+	}
+	if o.SortBy != "" {
+		query.Set("sort", o.SortBy)
+	}
+	if o.Descending {
+		query.Set("order", "desc")
+	}
+	if o.IsHeadquarter != nil {
+		query.Set("isHeadquarter", strconv.FormatBool(*o.IsHeadquarter))
+	}
+	if o.Town != "" {
+		query.Set("town", o.Town)
+	}
+	return query
+}
+
+// GetCountry returns a page of the codes of the country with the given ISO2
+// code.
+func (c *Client) GetCountry(ctx context.Context, iso2 string, opts CountryOptions) (models.SwiftCodeCountry, error) {
+	var country models.SwiftCodeCountry
+	err := c.do(ctx, http.MethodGet, "/v1/swift-codes/country/"+url.PathEscape(iso2), opts.values(), nil, &country, true)
+	return country, err
+}
+
+// Search ranks the codes matching query. iso2 limits the search to a country
+// and a zero limit leaves the server default.
+func (c *Client) Search(ctx context.Context, query, iso2 string, limit int) (models.SwiftCodeSearch, error) {
+	values := url.Values{"q": {query}}
+	if iso2 != "" {
+		values.Set("country", iso2)
+	}
+	if limit > 0 {
+		values.Set("limit", strconv.Itoa(limit))
+	}
+	var search models.SwiftCodeSearch
+	err := c.do(ctx, http.MethodGet, "/v1/swift-codes/search", values, nil, &search, true)
+	return search, err
+}
+
+// Lookup resolves many SWIFT codes with one request. The results are in the
+// order of swiftCodes; the Details of found codes are models.SwiftCodeDetails.
+func (c *Client) Lookup(ctx context.Context, swiftCodes []string) ([]models.SwiftCodeLookupResult, error) {
+	var response struct {
+		Results []struct {
+			models.SwiftCodeLookupResult
+			Details json.RawMessage `json:"details,omitempty"`
+		} `json:"results"`
+	}
+	// Lookups only read, so they are retried like GET requests.
+	if err := c.do(ctx, http.MethodPost, "/v1/swift-codes/lookup", nil, swiftCodes, &response, true); err != nil {
+		return nil, err
+	}
+
+	results := make([]models.SwiftCodeLookupResult, len(response.Results))
+	for i, result := range response.Results {
+		results[i] = result.SwiftCodeLookupResult
+		if len(result.Details) > 0 {
+			var details models.SwiftCodeDetails
+			if err := json.Unmarshal(result.Details, &details); err != nil {
+				return nil, err
+			}
+			results[i].Details = details
+		}
+	}
+	return results, nil
+}
+
+// Add stores a new SWIFT code.
+func (c *Client) Add(ctx context.Context, code models.SwiftCodeBranch) error {
+	return c.do(ctx, http.MethodPost, "/v1/swift-codes", nil, code, nil, false)
+}
+
+// Delete deletes a SWIFT code. Deleting a headquarter that still has branches
+// fails with models.ErrConflict.
+func (c *Client) Delete(ctx context.Context, swiftCode string) error {
+	return c.do(ctx, http.MethodDelete, "/v1/swift-codes/"+url.PathEscape(swiftCode), nil, nil, nil, true)
+}
+
+// DeleteCascade deletes a headquarter together with its branches, or a branch
+// on its own, and returns the deleted codes. They are nil when the codes were
+// deleted by an attempt whose response was lost.
+func (c *Client) DeleteCascade(ctx context.Context, swiftCode string) ([]string, error) {
+	var response struct {
+		Removed []string `json:"removed"`
+	}
+	err := c.do(ctx, http.MethodDelete, "/v1/swift-codes/"+url.PathEscape(swiftCode), url.Values{"cascade": {"true"}}, nil, &response, true)
+	return response.Removed, err
+}
+
+// do sends a request, retrying it while the server fails, and decodes the
+// response into out unless it is nil. Requests that aren't idempotent are only
+// retried when they weren't sent. A retried DELETE that finds nothing to delete
+// succeeds, since an earlier attempt may have deleted it.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, in, out any, idempotent bool) error {
+	target := c.baseURL.JoinPath(path)
+	target.RawQuery = query.Encode()
+
+	var body []byte
+	if in != nil {
+		var err error
+		if body, err = json.Marshal(in); err != nil {
+			return err
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := c.send(ctx, method, target.String(), body, out)
+		if attempt > 0 && method == http.MethodDelete && errors.Is(err, models.ErrNotFound) {
+			return nil
+		}
+		if attempt == c.maxRetries || !retryable(ctx, err, idempotent) {
+			return err
+		}
+		timer := time.NewTimer(c.delay(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+func (c *Client) send(ctx context.Context, method, target string, body []byte, out any) error {
+	req, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json, "+models.ProblemContentType)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return newAPIError(resp)
+	}
+	if out == nil {
+		_, err = io.Copy(io.Discard, resp.Body)
+		return err
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// retryable tells whether a failed attempt may succeed when sent again: the
+// server failed, or it couldn't be reached while ctx is still live. A request
+// that isn't idempotent may have been applied by then, so it is only retried
+// when it wasn't sent.
+func retryable(ctx context.Context, err error, idempotent bool) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	if !idempotent {
+		return unsent(err)
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= http.StatusInternalServerError
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+// unsent tells whether err means the request never reached the server: the
+// host couldn't be resolved or connected to.
+func unsent(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+func (c *Client) delay(attempt int) time.Duration {
+	delay := c.backoff << attempt
+	if delay <= 0 || delay > c.maxBackoff {
+		return c.maxBackoff
+	}
+	return delay
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"RemitlyTask/src/models"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// APIError is a response of the API with an error status. Message is the
+// detail of its problem document, or the status text when it has none.
+type APIError struct {
+	StatusCode int
+	Message    string
+	Problem    models.Problem
+}
+
+func (e *APIError) Error() string {
+	return strconv.Itoa(e.StatusCode) + " " + e.Message
+}
+
+// Is matches the domain error of models the API reported, so callers can test
+// for errors.Is(err, models.ErrNotFound) as with the service.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case models.ErrValidation:
+		return e.Problem.Code == models.ErrorCodeValidation
+	case models.ErrNotFound:
+		return e.Problem.Code == models.ErrorCodeNotFound
+	case models.ErrAlreadyExists:
+		return e.Problem.Code == models.ErrorCodeAlreadyExists
+	case models.ErrConflict:
+		return e.Problem.Code == models.ErrorCodeConflict
+	}
+	return false
+}
+
+func newAPIError(resp *http.Response) *APIError {
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err == nil && json.Unmarshal(body, &apiErr.Problem) == nil {
+		apiErr.Message = apiErr.Problem.Detail
+		if apiErr.Message == "" {
+			apiErr.Message = apiErr.Problem.Message
+		}
+	}
+	if apiErr.Message == "" {
+		apiErr.Message = http.StatusText(resp.StatusCode)
+	}
+	return apiErr
+}
@@ -0,0 +1,321 @@
+package config
+
+import (
+	"RemitlyTask/src/proxy"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+const DefaultEnvFile = "db.env"
+
+const (
+	StoragePostgres = "postgres"
+	StorageSQLite   = "sqlite"
+	StorageMemory   = "memory"
+	StorageEmbedded = "embedded"
+)
+
+type Config struct {
+	Server   ServerConfig   `yaml:"server" toml:"server"`
+	Storage  StorageConfig  `yaml:"storage" toml:"storage"`
+	Database DatabaseConfig `yaml:"database" toml:"database"`
+	LogLevel string         `yaml:"logLevel" toml:"logLevel"`
+}
+
+// StorageConfig selects the repository backend. SeedFile is a SWIFT directory
+// file loaded into the storage when it is empty. The embedded storage serves
+// the directory built into the binary and can't be written to. AutoMigrate
+// applies pending migrations of the SQL backends when they are opened.
+// Deleted codes are purged for good once they have been deleted for
+// DeletedRetention, checked every PurgeInterval, 0 to never purge them.
+type StorageConfig struct {
+	Driver           string   `yaml:"driver" toml:"driver"`
+	SQLitePath       string   `yaml:"sqlitePath" toml:"sqlitePath"`
+	SeedFile         string   `yaml:"seedFile" toml:"seedFile"`
+	AutoMigrate      bool     `yaml:"autoMigrate" toml:"autoMigrate"`
+	PurgeInterval    Duration `yaml:"purgeInterval" toml:"purgeInterval"`
+	DeletedRetention Duration `yaml:"deletedRetention" toml:"deletedRetention"`
+}
+
+// ServerConfig holds the HTTP server settings. RequestTimeout is the deadline
+// of the context each request runs its queries with, 0 for none.
+// LegacyErrorMessage adds the message field of the earlier error format to
+// problem documents. GRPCAddr is the address of the gRPC server, empty to
+// run without it. TrustedProxies are the IPs or CIDR ranges of the proxies
+// whose X-Forwarded-For and X-Actor headers are trusted, none by default.
+type ServerConfig struct {
+	Addr            string   `yaml:"addr" toml:"addr"`
+	GRPCAddr        string   `yaml:"grpcAddr" toml:"grpcAddr"`
+	ReadTimeout     Duration `yaml:"readTimeout" toml:"readTimeout"`
+	WriteTimeout    Duration `yaml:"writeTimeout" toml:"writeTimeout"`
+	IdleTimeout     Duration `yaml:"idleTimeout" toml:"idleTimeout"`
+	ShutdownTimeout Duration `yaml:"shutdownTimeout" toml:"shutdownTimeout"`
+	RequestTimeout  Duration `yaml:"requestTimeout" toml:"requestTimeout"`
+	TrustedProxies  []string `yaml:"trustedProxies" toml:"trustedProxies"`
+
+	LegacyErrorMessage bool `yaml:"legacyErrorMessage" toml:"legacyErrorMessage"`
+}
+
+type DatabaseConfig struct {
+	Host            string   `yaml:"host" toml:"host"`
+	Port            int      `yaml:"port" toml:"port"`
+	User            string   `yaml:"user" toml:"user"`
+	Password        string   `yaml:"password" toml:"password"`
+	Name            string   `yaml:"name" toml:"name"`
+	SSLMode         string   `yaml:"sslMode" toml:"sslMode"`
+	MaxOpenConns    int      `yaml:"maxOpenConns" toml:"maxOpenConns"`
+	MaxIdleConns    int      `yaml:"maxIdleConns" toml:"maxIdleConns"`
+	ConnMaxLifetime Duration `yaml:"connMaxLifetime" toml:"connMaxLifetime"`
+	ConnectTimeout  Duration `yaml:"connectTimeout" toml:"connectTimeout"`
+}
+
+// Duration is a time.Duration written as a string such as "5s" in
+// configuration files.
+type Duration time.Duration
+
+func (d *Duration) UnmarshalText(text []byte) error {
+	parsed, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+func (d *Duration) UnmarshalYAML(node *yaml.Node) error {
+	return d.UnmarshalText([]byte(node.Value))
+}
+
+func (d Duration) String() string {
+	return time.Duration(d).String()
+}
+
+func Default() Config {
+	return Config{
+		Server: ServerConfig{
+			Addr:            ":8080",
+			GRPCAddr:        ":9090",
+			ReadTimeout:     Duration(10 * time.Second),
+			WriteTimeout:    Duration(30 * time.Second),
+			IdleTimeout:     Duration(60 * time.Second),
+			ShutdownTimeout: Duration(10 * time.Second),
+			RequestTimeout:  Duration(15 * time.Second),
+		},
+		Storage: StorageConfig{
+			Driver:           StoragePostgres,
+			SQLitePath:       "swift_codes.db",
+			AutoMigrate:      true,
+			PurgeInterval:    Duration(time.Hour),
+			DeletedRetention: Duration(30 * 24 * time.Hour),
+		},
+		Database: DatabaseConfig{
+			Host:            "localhost",
+			Port:            5432,
+			SSLMode:         "disable",
+			MaxOpenConns:    10,
+			MaxIdleConns:    5,
+			ConnMaxLifetime: Duration(30 * time.Minute),
+			ConnectTimeout:  Duration(5 * time.Second),
+		},
+		LogLevel: "info",
+	}
+}
+
+// Sources lists where settings are read from. Later sources override earlier
+// ones: defaults, File, EnvFile, the process environment and finally Flags,
+// which maps flag names to their values.
+type Sources struct {
+	File    string
+	EnvFile string
+	Flags   map[string]string
+}
+
+func Load(sources Sources) (Config, error) {
+	cfg := Default()
+
+	if sources.File != "" {
+		if err := readFile(sources.File, &cfg); err != nil {
+			return Config{}, err
+		}
+	}
+
+	env, err := readEnv(sources.EnvFile)
+	if err != nil {
+		return Config{}, err
+	}
+	for _, s := range settings {
+		if value, ok := env[s.env]; ok {
+			if err := s.set(&cfg, strings.TrimSpace(value)); err != nil {
+				return Config{}, fmt.Errorf("%s: %w", s.env, err)
+			}
+		}
+	}
+
+	for _, s := range settings {
+		if value, ok := sources.Flags[s.flag]; ok {
+			if err := s.set(&cfg, value); err != nil {
+				return Config{}, fmt.Errorf("-%s: %w", s.flag, err)
+			}
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+func readFile(path string, cfg *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(strings.NewReader(string(data)))
+		decoder.KnownFields(true)
+		err = decoder.Decode(cfg)
+	case ".toml":
+		err = toml.NewDecoder(strings.NewReader(string(data))).DisallowUnknownFields().Decode(cfg)
+	default:
+		return fmt.Errorf("config file %s: unsupported format, use .yaml, .yml or .toml", path)
+	}
+	if err != nil {
+		return fmt.Errorf("config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// readEnv merges the optional env file with the process environment, which
+// takes precedence.
+func readEnv(envFile string) (map[string]string, error) {
+	env := make(map[string]string)
+	if envFile != "" {
+		values, err := godotenv.Read(envFile)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("env file %s: %w", envFile, err)
+		}
+		for key, value := range values {
+			env[key] = value
+		}
+	}
+	for _, s := range settings {
+		if value, ok := os.LookupEnv(s.env); ok {
+			env[s.env] = value
+		}
+	}
+	return env, nil
+}
+
+func lookupEnv(key, fallback string) string {
+	if value, ok := os.LookupEnv(key); ok {
+		return value
+	}
+	return fallback
+}
+
+func (c Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	if _, _, err := net.SplitHostPort(c.Server.Addr); err != nil {
+		errs = append(errs, fmt.Errorf("server address %q: %w", c.Server.Addr, err))
+	}
+	if c.Server.GRPCAddr != "" {
+		if _, _, err := net.SplitHostPort(c.Server.GRPCAddr); err != nil {
+			errs = append(errs, fmt.Errorf("gRPC address %q: %w", c.Server.GRPCAddr, err))
+		}
+	}
+	check(c.Server.ReadTimeout >= 0, "server read timeout can't be negative")
+	check(c.Server.WriteTimeout >= 0, "server write timeout can't be negative")
+	check(c.Server.IdleTimeout >= 0, "server idle timeout can't be negative")
+	check(c.Server.ShutdownTimeout >= 0, "server shutdown timeout can't be negative")
+	check(c.Server.RequestTimeout >= 0, "server request timeout can't be negative")
+	if _, err := proxy.Parse(c.Server.TrustedProxies); err != nil {
+		errs = append(errs, err)
+	}
+
+	switch c.Storage.Driver {
+	case StoragePostgres:
+		check(c.Database.Host != "", "database host can't be empty")
+		check(c.Database.Port > 0 && c.Database.Port <= 65535, "database port %d is out of range", c.Database.Port)
+		check(c.Database.User != "", "database user can't be empty")
+		check(c.Database.Name != "", "database name can't be empty")
+	case StorageSQLite:
+		check(c.Storage.SQLitePath != "", "SQLite path can't be empty")
+	case StorageMemory:
+	case StorageEmbedded:
+		check(c.Storage.SeedFile == "", "a seed file can't be used with the embedded storage")
+	default:
+		errs = append(errs, fmt.Errorf("storage driver %q must be %s, %s, %s or %s",
+			c.Storage.Driver, StoragePostgres, StorageSQLite, StorageMemory, StorageEmbedded))
+	}
+	check(c.Storage.PurgeInterval >= 0, "purge interval can't be negative")
+	check(c.Storage.DeletedRetention >= 0, "deleted code retention can't be negative")
+	check(c.Database.MaxOpenConns >= 0, "database max open connections can't be negative")
+	check(c.Database.MaxIdleConns >= 0, "database max idle connections can't be negative")
+	check(c.Database.MaxOpenConns == 0 || c.Database.MaxIdleConns <= c.Database.MaxOpenConns,
+		"database max idle connections can't exceed max open connections")
+	check(c.Database.ConnMaxLifetime >= 0, "database connection max lifetime can't be negative")
+	check(c.Database.ConnectTimeout >= 0, "database connect timeout can't be negative")
+
+	if _, err := c.SlogLevel(); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s StorageConfig) ReadOnly() bool {
+	return s.Driver == StorageEmbedded
+}
+
+func (c Config) SlogLevel() (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.LogLevel)); err != nil {
+		return 0, fmt.Errorf("log level %q must be debug, info, warn or error", c.LogLevel)
+	}
+	return level, nil
+}
+
+// DSN is the Postgres connection string of the database settings.
+func (d DatabaseConfig) DSN() string {
+	parts := []string{
+		"host=" + quoteDSN(d.Host),
+		"port=" + strconv.Itoa(d.Port),
+		"user=" + quoteDSN(d.User),
+		"password=" + quoteDSN(d.Password),
+		"dbname=" + quoteDSN(d.Name),
+		"sslmode=" + quoteDSN(d.SSLMode),
+	}
+	if d.ConnectTimeout > 0 {
+		seconds := int((time.Duration(d.ConnectTimeout) + time.Second - 1) / time.Second)
+		parts = append(parts, "connect_timeout="+strconv.Itoa(seconds))
+	}
+	return strings.Join(parts, " ")
+}
+
+func quoteDSN(value string) string {
+	if value != "" && !strings.ContainsAny(value, ` '\`) {
+		return value
+	}
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `'`, `\'`)
+	return "'" + value + "'"
+}
@@ -0,0 +1,140 @@
+package config
+
+import (
+	"flag"
+	"strconv"
+	"strings"
+)
+
+// setting is a configuration value that can be given as a flag or as an
+// environment variable.
+type setting struct {
+	flag  string
+	env   string
+	usage string
+	set   func(cfg *Config, value string) error
+}
+
+var settings = []setting{
+	{"addr", "HTTP_ADDR", "address the HTTP server listens on", setString(func(c *Config) *string { return &c.Server.Addr })},
+	{"grpc-addr", "GRPC_ADDR", "address the gRPC server listens on, empty to disable it", setString(func(c *Config) *string { return &c.Server.GRPCAddr })},
+	{"read-timeout", "HTTP_READ_TIMEOUT", "maximum duration for reading a request", setDuration(func(c *Config) *Duration { return &c.Server.ReadTimeout })},
+	{"write-timeout", "HTTP_WRITE_TIMEOUT", "maximum duration for writing a response", setDuration(func(c *Config) *Duration { return &c.Server.WriteTimeout })},
+	{"idle-timeout", "HTTP_IDLE_TIMEOUT", "how long idle keep-alive connections are kept open", setDuration(func(c *Config) *Duration { return &c.Server.IdleTimeout })},
+	{"shutdown-timeout", "HTTP_SHUTDOWN_TIMEOUT", "how long in-flight requests may run after a shutdown signal", setDuration(func(c *Config) *Duration { return &c.Server.ShutdownTimeout })},
+	{"request-timeout", "HTTP_REQUEST_TIMEOUT", "deadline for the queries of a single request, 0 for none", setDuration(func(c *Config) *Duration { return &c.Server.RequestTimeout })},
+	{"trusted-proxies", "TRUSTED_PROXIES", "comma-separated IPs or CIDR ranges of the proxies trusted to set X-Forwarded-For and X-Actor", setStrings(func(c *Config) *[]string { return &c.Server.TrustedProxies })},
+	{"legacy-error-message", "HTTP_LEGACY_ERROR_MESSAGE", "add the message field of the earlier error format to error responses", setBool(func(c *Config) *bool { return &c.Server.LegacyErrorMessage })},
+	{"storage", "STORAGE_DRIVER", "storage backend: postgres, sqlite, memory or embedded", setString(func(c *Config) *string { return &c.Storage.Driver })},
+	{"sqlite-path", "SQLITE_PATH", "SQLite database file, or :memory:", setString(func(c *Config) *string { return &c.Storage.SQLitePath })},
+	{"seed-file", "STORAGE_SEED_FILE", "SWIFT directory file loaded when the storage is empty", setString(func(c *Config) *string { return &c.Storage.SeedFile })},
+	{"auto-migrate", "AUTO_MIGRATE", "apply pending migrations on start", setBool(func(c *Config) *bool { return &c.Storage.AutoMigrate })},
+	{"purge-interval", "PURGE_INTERVAL", "how often deleted codes past their retention are purged, 0 to never purge them", setDuration(func(c *Config) *Duration { return &c.Storage.PurgeInterval })},
+	{"deleted-retention", "DELETED_RETENTION", "how long deleted codes can be restored before they are purged", setDuration(func(c *Config) *Duration { return &c.Storage.DeletedRetention })},
+	{"db-host", "DB_HOST", "database host", setString(func(c *Config) *string { return &c.Database.Host })},
+	{"db-port", "DB_PORT", "database port", setInt(func(c *Config) *int { return &c.Database.Port })},
+	{"db-user", "POSTGRES_USER", "database user", setString(func(c *Config) *string { return &c.Database.User })},
+	{"db-password", "POSTGRES_PASSWORD", "database password", setString(func(c *Config) *string { return &c.Database.Password })},
+	{"db-name", "POSTGRES_DB", "database name", setString(func(c *Config) *string { return &c.Database.Name })},
+	{"db-sslmode", "DB_SSLMODE", "Postgres sslmode", setString(func(c *Config) *string { return &c.Database.SSLMode })},
+	{"db-max-open-conns", "DB_MAX_OPEN_CONNS", "maximum open database connections, 0 for no limit", setInt(func(c *Config) *int { return &c.Database.MaxOpenConns })},
+	{"db-max-idle-conns", "DB_MAX_IDLE_CONNS", "maximum idle database connections", setInt(func(c *Config) *int { return &c.Database.MaxIdleConns })},
+	{"db-conn-max-lifetime", "DB_CONN_MAX_LIFETIME", "maximum lifetime of a database connection, 0 for no limit", setDuration(func(c *Config) *Duration { return &c.Database.ConnMaxLifetime })},
+	{"db-connect-timeout", "DB_CONNECT_TIMEOUT", "timeout for opening a database connection", setDuration(func(c *Config) *Duration { return &c.Database.ConnectTimeout })},
+	{"log-level", "LOG_LEVEL", "debug, info, warn or error", setString(func(c *Config) *string { return &c.LogLevel })},
+}
+
+func setString(field func(*Config) *string) func(*Config, string) error {
+	return func(cfg *Config, value string) error {
+		*field(cfg) = value
+		return nil
+	}
+}
+
+// setStrings splits a comma-separated list. An empty value is an empty list.
+func setStrings(field func(*Config) *[]string) func(*Config, string) error {
+	return func(cfg *Config, value string) error {
+		var values []string
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+		*field(cfg) = values
+		return nil
+	}
+}
+
+func setInt(field func(*Config) *int) func(*Config, string) error {
+	return func(cfg *Config, value string) error {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		*field(cfg) = parsed
+		return nil
+	}
+}
+
+func setBool(field func(*Config) *bool) func(*Config, string) error {
+	return func(cfg *Config, value string) error {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		*field(cfg) = parsed
+		return nil
+	}
+}
+
+func setDuration(field func(*Config) *Duration) func(*Config, string) error {
+	return func(cfg *Config, value string) error {
+		return field(cfg).UnmarshalText([]byte(value))
+	}
+}
+
+// Flags are the command line flags of every setting, plus -config and
+// -env-file to choose the files settings are read from.
+type Flags struct {
+	fs      *flag.FlagSet
+	file    *string
+	envFile *string
+	values  map[string]*string
+}
+
+func BindFlags(fs *flag.FlagSet) *Flags {
+	f := &Flags{
+		fs:      fs,
+		file:    fs.String("config", "", "YAML or TOML configuration file (env CONFIG_FILE)"),
+		envFile: fs.String("env-file", DefaultEnvFile, "optional file of environment variables (env ENV_FILE)"),
+		values:  make(map[string]*string),
+	}
+	for _, s := range settings {
+		f.values[s.flag] = fs.String(s.flag, "", s.usage+" (env "+s.env+")")
+	}
+	return f
+}
+
+// Load reads the configuration once the flag set has been parsed.
+func (f *Flags) Load() (Config, error) {
+	sources := Sources{
+		File:    lookupEnv("CONFIG_FILE", *f.file),
+		EnvFile: lookupEnv("ENV_FILE", *f.envFile),
+		Flags:   make(map[string]string),
+	}
+
+	f.fs.Visit(func(fl *flag.Flag) {
+		switch fl.Name {
+		case "config":
+			sources.File = *f.file
+		case "env-file":
+			sources.EnvFile = *f.envFile
+		default:
+			if value, ok := f.values[fl.Name]; ok {
+				sources.Flags[fl.Name] = *value
+			}
+		}
+	})
+
+	return Load(sources)
+}
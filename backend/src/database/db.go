@@ -1,38 +1,60 @@
 package database
 
 import (
-	"RemitlyTask/src/models"
-	"log"
-	"os"
-	"sync"
+	"RemitlyTask/src/config"
+	"RemitlyTask/src/migrations"
+	"fmt"
+	"time"
 
-	"github.com/joho/godotenv"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
-var (
-	DB   *gorm.DB
-	once sync.Once
-)
+// Connect connects to the configured database and sizes its connection pool.
+func Connect(cfg config.DatabaseConfig) (*gorm.DB, error) {
+	db, err := gorm.Open(postgres.Open(cfg.DSN()), &gorm.Config{TranslateError: true})
+	if err != nil {
+		return nil, fmt.Errorf("connecting to database: %w", err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(time.Duration(cfg.ConnMaxLifetime))
+	return db, nil
+}
 
-func init() {
-	once.Do(func() {
-		err := godotenv.Load("db.env")
-		if err != nil {
-			log.Fatal("Error loading .env file")
-		}
-		host := os.Getenv("DB_HOST")
-		user := os.Getenv("POSTGRES_USER")
-		password := os.Getenv("POSTGRES_PASSWORD")
-		databaseName := os.Getenv("POSTGRES_DB")
-		port := os.Getenv("DB_PORT")
+// Open connects to the configured database and applies pending migrations.
+func Open(cfg config.DatabaseConfig) (*gorm.DB, error) {
+	db, err := Connect(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err := Migrate(db, migrations.DialectPostgres); err != nil {
+		Close(db)
+		return nil, err
+	}
+	return db, nil
+}
+
+func Migrate(db *gorm.DB, dialect string) error {
+	migrator, err := migrations.NewMigrator(db, dialect)
+	if err != nil {
+		return err
+	}
+	if _, err := migrator.Up(); err != nil {
+		return fmt.Errorf("migrating database: %w", err)
+	}
+	return nil
+}
 
-		dsn := "host=" + host + " user=" + user + " password=" + password + " dbname=" + databaseName + " port=" + port
-		DB, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
-		if err != nil {
-			log.Fatal("Failed to connect to database:", err)
-		}
-		DB.AutoMigrate(&models.SwiftCode{})
-	})
+func Close(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
 }
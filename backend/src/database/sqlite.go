@@ -0,0 +1,46 @@
+package database
+
+import (
+	"RemitlyTask/src/migrations"
+	"fmt"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+// ConnectSQLite opens or creates the SQLite database at path. ":memory:" gives
+// a database that lives as long as the process.
+func ConnectSQLite(path string) (*gorm.DB, error) {
+	dsn := path + "?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)"
+	if path == ":memory:" {
+		dsn = path
+	}
+
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{TranslateError: true})
+	if err != nil {
+		return nil, fmt.Errorf("opening SQLite database: %w", err)
+	}
+
+	if path == ":memory:" {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return nil, err
+		}
+		// Every connection to ":memory:" would get its own empty database.
+		sqlDB.SetMaxOpenConns(1)
+	}
+	return db, nil
+}
+
+// OpenSQLite opens the SQLite database at path and applies pending migrations.
+func OpenSQLite(path string) (*gorm.DB, error) {
+	db, err := ConnectSQLite(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := Migrate(db, migrations.DialectSQLite); err != nil {
+		Close(db)
+		return nil, err
+	}
+	return db, nil
+}
@@ -0,0 +1,147 @@
+package export
+
+import (
+	"RemitlyTask/src/importer"
+	"RemitlyTask/src/models"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+const (
+	FormatCSV    = "csv"
+	FormatJSON   = "json"
+	FormatNDJSON = "ndjson"
+)
+
+// Record is a code with every column of the SWIFT directory file.
+type Record struct {
+	CountryISO2 string `json:"countryISO2"`
+	SwiftCode   string `json:"swiftCode"`
+	CodeType    string `json:"codeType"`
+	BankName    string `json:"bankName"`
+	Address     string `json:"address"`
+	TownName    string `json:"townName"`
+	CountryName string `json:"countryName"`
+	TimeZone    string `json:"timeZone"`
+}
+
+func NewRecord(code models.SwiftCode) Record {
+	return Record{
+		CountryISO2: code.CountryISO2,
+		SwiftCode:   code.SwiftCode,
+		CodeType:    code.CodeType,
+		BankName:    code.Name,
+		Address:     code.Address,
+		TownName:    code.TownName,
+		CountryName: code.CountryName,
+		TimeZone:    code.TimeZone,
+	}
+}
+
+// Writer writes codes one at a time. Close must be called after the last code
+// to complete the output.
+type Writer interface {
+	Write(code models.SwiftCode) error
+	Close() error
+}
+
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return &csvWriter{w: csv.NewWriter(w)}, nil
+	case FormatJSON:
+		return &jsonWriter{w: w}, nil
+	case FormatNDJSON:
+		return &ndjsonWriter{enc: json.NewEncoder(w)}, nil
+	}
+	return nil, fmt.Errorf("unknown format %q", format)
+}
+
+func ContentType(format string) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatNDJSON:
+		return "application/x-ndjson"
+	}
+	return "application/json; charset=utf-8"
+}
+
+// csvWriter uses the layout of the import file so exports can be imported
+// again.
+type csvWriter struct {
+	w             *csv.Writer
+	headerWritten bool
+}
+
+func (c *csvWriter) writeHeader() error {
+	if c.headerWritten {
+		return nil
+	}
+	c.headerWritten = true
+	return c.w.Write(importer.Header)
+}
+
+func (c *csvWriter) Write(code models.SwiftCode) error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+	if err := c.w.Write(importer.Record(code)); err != nil {
+		return err
+	}
+	return c.w.Error()
+}
+
+func (c *csvWriter) Close() error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+type jsonWriter struct {
+	w     io.Writer
+	count int
+}
+
+func (j *jsonWriter) Write(code models.SwiftCode) error {
+	separator := ","
+	if j.count == 0 {
+		separator = "["
+	}
+	j.count++
+
+	data, err := json.Marshal(NewRecord(code))
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(j.w, separator); err != nil {
+		return err
+	}
+	_, err = j.w.Write(data)
+	return err
+}
+
+func (j *jsonWriter) Close() error {
+	end := "]\n"
+	if j.count == 0 {
+		end = "[]\n"
+	}
+	_, err := io.WriteString(j.w, end)
+	return err
+}
+
+type ndjsonWriter struct {
+	enc *json.Encoder
+}
+
+func (n *ndjsonWriter) Write(code models.SwiftCode) error {
+	return n.enc.Encode(NewRecord(code))
+}
+
+func (n *ndjsonWriter) Close() error {
+	return nil
+}
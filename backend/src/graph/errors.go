@@ -0,0 +1,32 @@
+package graph
+
+import (
+	"RemitlyTask/src/models"
+	"context"
+	"errors"
+
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+)
+
+// annotate sets the code of the domain error a resolver failed with in the
+// extensions of err, using the codes of the REST problem documents. Internal
+// errors are reported without their message.
+func annotate(err *gqlerrors.QueryError) {
+	code := models.ErrorCodeInternal
+	switch {
+	case errors.Is(err.ResolverError, context.DeadlineExceeded):
+		code = models.ErrorCodeTimeout
+	case errors.Is(err.ResolverError, models.ErrValidation):
+		code = models.ErrorCodeValidation
+	case errors.Is(err.ResolverError, models.ErrNotFound):
+		code = models.ErrorCodeNotFound
+	}
+
+	if code == models.ErrorCodeInternal {
+		err.Message = "internal error"
+	}
+	if err.Extensions == nil {
+		err.Extensions = make(map[string]interface{})
+	}
+	err.Extensions["code"] = code
+}
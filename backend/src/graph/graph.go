@@ -0,0 +1,118 @@
+// Package graph serves the SWIFT codes as a GraphQL graph of codes,
+// headquarters and countries, resolved through the same ISwiftCodeService as
+// the REST API. The types are defined in schema.graphql.
+package graph
+
+import (
+	"RemitlyTask/src/models"
+	"RemitlyTask/src/services"
+	"context"
+	_ "embed"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+const (
+	MaxPageSize = 1000
+	// MaxDepth bounds the nesting of a query, which could otherwise walk from
+	// codes to countries and back indefinitely.
+	MaxDepth = 10
+)
+
+type Schema struct {
+	schema  *graphql.Schema
+	service services.ISwiftCodeService
+}
+
+func NewSchema(service services.ISwiftCodeService) *Schema {
+	return &Schema{
+		schema:  graphql.MustParseSchema(schemaSDL, &query{}, graphql.MaxDepth(MaxDepth)),
+		service: service,
+	}
+}
+
+// Exec runs a query. Each run gets its own loaders, so code lookups are
+// batched and both codes and country pages are cached within a query, but
+// never shared between queries.
+func (s *Schema) Exec(ctx context.Context, queryString, operationName string, variables map[string]interface{}) *graphql.Response {
+	ctx = context.WithValue(ctx, requestKey{}, newRequest(s.service))
+	response := s.schema.Exec(ctx, queryString, operationName, variables)
+	for _, err := range response.Errors {
+		if err.ResolverError != nil {
+			annotate(err)
+		}
+	}
+	return response
+}
+
+type requestKey struct{}
+
+// request holds the loaders of one query. codes resolves SWIFT codes with
+// LookupSwiftCodes; pages caches the country pages already read. Pages are not
+// batched: the size of a page is only known once its field is resolved, so
+// nothing announces them, and each distinct page costs its own query.
+type request struct {
+	service services.ISwiftCodeService
+	codes   *loader[string, models.SwiftCodeLookupResult]
+	pages   *loader[pageKey, models.SwiftCodeCountry]
+}
+
+type pageKey struct {
+	iso2  string
+	first int
+	after string
+}
+
+func newRequest(service services.ISwiftCodeService) *request {
+	return &request{
+		service: service,
+		codes: newLoader(func(ctx context.Context, swiftCodes []string) (map[string]models.SwiftCodeLookupResult, error) {
+			results, err := service.LookupSwiftCodes(ctx, swiftCodes)
+			if err != nil {
+				return nil, err
+			}
+			values := make(map[string]models.SwiftCodeLookupResult, len(results))
+			for _, result := range results {
+				values[result.SwiftCode] = result
+			}
+			return values, nil
+		}),
+		pages: newLoader(func(ctx context.Context, keys []pageKey) (map[pageKey]models.SwiftCodeCountry, error) {
+			values := make(map[pageKey]models.SwiftCodeCountry, len(keys))
+			for _, key := range keys {
+				query := models.CountryQuery{Limit: key.first, SortBy: models.SortBySwiftCode}
+				if key.after != "" {
+					after, err := models.DecodeCursor(key.after)
+					if err != nil {
+						return nil, err
+					}
+					query.After = &after
+				}
+				page, err := service.GetSwiftCodesByCountry(ctx, key.iso2, query)
+				if err != nil {
+					return nil, err
+				}
+				values[key] = page.(models.SwiftCodeCountry)
+			}
+			return values, nil
+		}),
+	}
+}
+
+func requestFrom(ctx context.Context) *request {
+	return ctx.Value(requestKey{}).(*request)
+}
+
+// details loads the details of a code: models.SwiftCodeDetails for a
+// headquarter, models.SwiftCodeBranch for a branch. ok is false when the code
+// is not found.
+func (r *request) details(ctx context.Context, swiftCode string) (details interface{}, ok bool, err error) {
+	result, _, err := r.codes.load(ctx, swiftCode)
+	if err != nil || !result.Found {
+		return nil, false, err
+	}
+	return result.Details, true, nil
+}
@@ -0,0 +1,80 @@
+package graph
+
+import (
+	"context"
+	"sync"
+)
+
+// loader fetches values in batches for the duration of one request. Resolvers
+// of a list announce the keys its items may load with want; the first load of
+// any of them then fetches every key wanted so far with a single call, instead
+// of one call per item. Fetched values are kept, so a key is fetched once.
+type loader[K comparable, V any] struct {
+	fetch func(ctx context.Context, keys []K) (map[K]V, error)
+
+	mu      sync.Mutex
+	pending map[K]bool
+	batches map[K]*batch[K, V]
+}
+
+type batch[K comparable, V any] struct {
+	done   chan struct{}
+	values map[K]V
+	err    error
+}
+
+func newLoader[K comparable, V any](fetch func(ctx context.Context, keys []K) (map[K]V, error)) *loader[K, V] {
+	return &loader[K, V]{
+		fetch:   fetch,
+		pending: make(map[K]bool),
+		batches: make(map[K]*batch[K, V]),
+	}
+}
+
+func (l *loader[K, V]) want(keys ...K) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, key := range keys {
+		if _, ok := l.batches[key]; !ok {
+			l.pending[key] = true
+		}
+	}
+}
+
+// load returns the value fetched for key. ok is false when the fetch didn't
+// return one.
+func (l *loader[K, V]) load(ctx context.Context, key K) (value V, ok bool, err error) {
+	l.mu.Lock()
+	b, fetched := l.batches[key]
+	if !fetched {
+		b = &batch[K, V]{done: make(chan struct{})}
+		keys := []K{key}
+		l.batches[key] = b
+		for pending := range l.pending {
+			if pending != key {
+				keys = append(keys, pending)
+				l.batches[pending] = b
+			}
+		}
+		clear(l.pending)
+		l.mu.Unlock()
+
+		func() {
+			defer close(b.done)
+			b.values, b.err = l.fetch(ctx, keys)
+		}()
+	} else {
+		l.mu.Unlock()
+		select {
+		case <-b.done:
+		case <-ctx.Done():
+			return value, false, ctx.Err()
+		}
+	}
+
+	if b.err != nil {
+		return value, false, b.err
+	}
+	value, ok = b.values[key]
+	return value, ok, nil
+}
@@ -0,0 +1,218 @@
+package graph
+
+import (
+	"RemitlyTask/src/models"
+	"RemitlyTask/src/validation"
+	"context"
+	"fmt"
+	"strings"
+)
+
+type query struct{}
+
+func (query) SwiftCode(ctx context.Context, args struct{ Code string }) (*swiftCodeResolver, error) {
+	if _, err := validation.ParseBIC(args.Code); err != nil {
+		return nil, err
+	}
+	req := requestFrom(ctx)
+	details, ok, err := req.details(ctx, args.Code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("SWIFT code %s %w", args.Code, models.ErrNotFound)
+	}
+
+	switch details := details.(type) {
+	case models.SwiftCodeDetails:
+		return &swiftCodeResolver{
+			req:         req,
+			code:        bank(details),
+			countryName: details.CountryName,
+			headquarter: &details,
+		}, nil
+	default:
+		branch := details.(models.SwiftCodeBranch)
+		return &swiftCodeResolver{
+			req: req,
+			code: models.SwiftCodeBank{
+				Address:     branch.Address,
+				BankName:    branch.BankName,
+				CountryISO2: branch.CountryISO2,
+				SwiftCode:   branch.SwiftCode,
+			},
+			countryName: branch.CountryName,
+		}, nil
+	}
+}
+
+func (query) Headquarter(ctx context.Context, args struct{ Code string }) (*headquarterResolver, error) {
+	bic, err := validation.ParseBIC(args.Code)
+	if err != nil {
+		return nil, err
+	}
+	if !bic.IsHeadquarter() {
+		return nil, fmt.Errorf("%w: %s is a branch code", models.ErrValidation, args.Code)
+	}
+	req := requestFrom(ctx)
+	details, ok, err := req.details(ctx, args.Code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("SWIFT code %s %w", args.Code, models.ErrNotFound)
+	}
+	return &headquarterResolver{req: req, details: details.(models.SwiftCodeDetails)}, nil
+}
+
+func (query) Country(ctx context.Context, args struct{ ISO2 string }) (*countryResolver, error) {
+	iso2 := strings.ToUpper(args.ISO2)
+	if len(iso2) != 2 {
+		return nil, &validation.FieldError{Field: validation.FieldCountryISO2, Value: args.ISO2, Err: validation.ErrInvalidISO2}
+	}
+	req := requestFrom(ctx)
+	name, err := req.service.GetCountryName(ctx, iso2)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		return nil, fmt.Errorf("country %s %w", iso2, models.ErrNotFound)
+	}
+	return &countryResolver{req: req, iso2: iso2, name: name}, nil
+}
+
+// swiftCodeResolver resolves a headquarter or branch code. headquarter is set
+// when the headquarter of the code is already known, and loaded otherwise.
+type swiftCodeResolver struct {
+	req         *request
+	code        models.SwiftCodeBank
+	countryName string
+	headquarter *models.SwiftCodeDetails
+}
+
+func (r *swiftCodeResolver) SwiftCode() string   { return r.code.SwiftCode }
+func (r *swiftCodeResolver) BankName() string    { return r.code.BankName }
+func (r *swiftCodeResolver) Address() string     { return r.code.Address }
+func (r *swiftCodeResolver) CountryISO2() string { return r.code.CountryISO2 }
+func (r *swiftCodeResolver) CountryName() string { return r.countryName }
+func (r *swiftCodeResolver) IsHeadquarter() bool {
+	return strings.HasSuffix(r.code.SwiftCode, validation.HeadquarterBranchCode)
+}
+func (r *swiftCodeResolver) Country() *countryResolver {
+	return &countryResolver{req: r.req, iso2: r.code.CountryISO2, name: r.countryName}
+}
+
+func (r *swiftCodeResolver) Headquarter(ctx context.Context) (*headquarterResolver, error) {
+	if r.headquarter != nil {
+		return &headquarterResolver{req: r.req, details: *r.headquarter}, nil
+	}
+	details, ok, err := r.req.details(ctx, headquarterCode(r.code.SwiftCode))
+	if err != nil || !ok {
+		return nil, err
+	}
+	return &headquarterResolver{req: r.req, details: details.(models.SwiftCodeDetails)}, nil
+}
+
+type headquarterResolver struct {
+	req     *request
+	details models.SwiftCodeDetails
+}
+
+func (r *headquarterResolver) SwiftCode() string   { return r.details.SwiftCode }
+func (r *headquarterResolver) BankName() string    { return r.details.BankName }
+func (r *headquarterResolver) Address() string     { return r.details.Address }
+func (r *headquarterResolver) CountryISO2() string { return r.details.CountryISO2 }
+func (r *headquarterResolver) CountryName() string { return r.details.CountryName }
+func (r *headquarterResolver) Country() *countryResolver {
+	return &countryResolver{req: r.req, iso2: r.details.CountryISO2, name: r.details.CountryName}
+}
+
+func (r *headquarterResolver) Branches() []*swiftCodeResolver {
+	branches := make([]*swiftCodeResolver, len(r.details.Branches))
+	for i, branch := range r.details.Branches {
+		branches[i] = &swiftCodeResolver{req: r.req, code: branch, countryName: r.details.CountryName, headquarter: &r.details}
+	}
+	return branches
+}
+
+type countryResolver struct {
+	req  *request
+	iso2 string
+	name string
+}
+
+func (r *countryResolver) ISO2() string { return r.iso2 }
+func (r *countryResolver) Name() string { return r.name }
+
+// SwiftCodes reads a page of the codes of the country. The headquarters of the
+// codes are announced to the code loader, so asking for the headquarter of
+// every code of the page costs a single lookup.
+func (r *countryResolver) SwiftCodes(ctx context.Context, args struct {
+	First int32
+	After *string
+}) (*connectionResolver, error) {
+	if args.First < 1 || args.First > MaxPageSize {
+		return nil, fmt.Errorf("%w: first must be between 1 and %d", models.ErrValidation, MaxPageSize)
+	}
+	key := pageKey{iso2: r.iso2, first: int(args.First)}
+	if args.After != nil {
+		after, err := models.DecodeCursor(*args.After)
+		if err != nil || after.SortBy != models.SortBySwiftCode || after.Descending {
+			return nil, fmt.Errorf("%w: after is not a cursor of this connection", models.ErrValidation)
+		}
+		key.after = *args.After
+	}
+
+	page, _, err := r.req.pages.load(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]*swiftCodeResolver, len(page.SwiftCodes))
+	headquarters := make([]string, len(page.SwiftCodes))
+	for i, code := range page.SwiftCodes {
+		nodes[i] = &swiftCodeResolver{req: r.req, code: code, countryName: page.CountryName}
+		headquarters[i] = headquarterCode(code.SwiftCode)
+	}
+	r.req.codes.want(headquarters...)
+
+	return &connectionResolver{nodes: nodes, nextCursor: page.NextCursor}, nil
+}
+
+type connectionResolver struct {
+	nodes      []*swiftCodeResolver
+	nextCursor string
+}
+
+func (r *connectionResolver) Nodes() []*swiftCodeResolver { return r.nodes }
+func (r *connectionResolver) PageInfo() *pageInfoResolver {
+	return &pageInfoResolver{nextCursor: r.nextCursor}
+}
+
+type pageInfoResolver struct {
+	nextCursor string
+}
+
+// EndCursor is the cursor of the next page, null on the last page.
+func (r *pageInfoResolver) EndCursor() *string {
+	if r.nextCursor == "" {
+		return nil
+	}
+	return &r.nextCursor
+}
+
+func (r *pageInfoResolver) HasNextPage() bool { return r.nextCursor != "" }
+
+func bank(details models.SwiftCodeDetails) models.SwiftCodeBank {
+	return models.SwiftCodeBank{
+		Address:       details.Address,
+		BankName:      details.BankName,
+		CountryISO2:   details.CountryISO2,
+		IsHeadquarter: details.IsHeadquarter,
+		SwiftCode:     details.SwiftCode,
+	}
+}
+
+func headquarterCode(swiftCode string) string {
+	return swiftCode[:8] + validation.HeadquarterBranchCode
+}
@@ -1,10 +1,28 @@
 package handlers
 
 const (
-	ErrFetchSwiftCodes    = "Failed to fetch SWIFT codes "
-	ErrNoSwiftCodeFound   = "No SWIFT code found "
-	ErrFailedToDelete     = "Could not delete a record"
-	ErrFailedToInsert     = "Error inserting to database "
-	ErrInvalidSwiftLength = "Invalid SWIFT code length. It must be either 8 or 11 characters long."
-	ErrInvalidISO2Length  = "Invalid ISO2 code length. It must be 2 characters long."
+	ErrFetchSwiftCodes   = "Failed to fetch SWIFT codes "
+	ErrNoSwiftCodeFound  = "No SWIFT code found "
+	ErrFetchHistory      = "Failed to fetch the history of "
+	ErrFailedToDelete    = "Could not delete a record"
+	ErrFailedToRestore   = "Could not restore "
+	ErrHasBranches       = "the headquarter has branches, delete them first or pass cascade=true."
+	ErrFailedToInsert    = "Error inserting to database "
+	ErrFailedToUpdate    = "Error updating a record "
+	ErrInvalidPatch      = "Invalid merge patch: "
+	ErrInvalidQuery      = "Invalid query parameter: "
+	ErrInvalidLookup     = "Invalid lookup request: "
+	ErrInvalidBulk       = "Invalid bulk request: "
+	ErrInvalidExport     = "Invalid export request: "
+	ErrInvalidGraphQL    = "Invalid GraphQL request: "
+	ErrFailedToExport    = "Error exporting swift codes"
+	ErrUnknownISO2       = "invalid ISO2 code."
+	ErrCountryMismatch   = "iso2 code must match with given country."
+	ErrSwiftCodeExists   = "swift code already exists"
+	ErrSwiftCodeRepeated = "swift code is repeated in the request"
+	ErrBatchNotInserted  = "not inserted because another code in the request was rejected"
+	ErrReadOnly          = "The SWIFT code directory is read-only in offline mode."
+	ErrRequestTimeout    = "The request took too long and was cancelled."
+	ErrInvalidSwiftCode  = "Invalid SWIFT code: "
+	ErrInvalidISO2Length = "Invalid ISO2 code length. It must be 2 characters long."
 )
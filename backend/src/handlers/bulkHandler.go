@@ -0,0 +1,237 @@
+package handlers
+
+import (
+	"RemitlyTask/src/models"
+	"RemitlyTask/src/validation"
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const ndjsonContentType = "application/x-ndjson"
+
+// bulkInsertBatch is the number of codes a non-atomic bulk request inserts
+// with one query.
+const bulkInsertBatch = 100
+
+// BulkAddSwiftCodes adds every code of a JSON array or NDJSON stream. With
+// atomic=true either all codes are inserted in one transaction or none are;
+// otherwise the codes are inserted in batches, each code is reported
+// separately, and the codes of a batch that fails are inserted one by one.
+func (h *SwiftCodeHandler) BulkAddSwiftCodes(c *gin.Context) {
+	atomic, err := strconv.ParseBool(c.DefaultQuery("atomic", "false"))
+	if err != nil {
+		c.Error(invalidRequest(ErrInvalidBulk+"atomic must be true or false.", err))
+		return
+	}
+
+	newCodes, err := readBulkBody(c)
+	if err != nil {
+		slog.Info("Error reading bulk request", "error", err)
+		c.Error(invalidRequest(ErrInvalidBulk+err.Error(), err))
+		return
+	}
+
+	if len(newCodes) == 0 || len(newCodes) > MaxBulkBatch {
+		c.Error(invalidRequest(ErrInvalidBulk+"between 1 and "+strconv.Itoa(MaxBulkBatch)+" SWIFT codes must be given.", nil))
+		return
+	}
+
+	result, validCodes, err := h.validateBulk(c.Request.Context(), newCodes)
+	if err != nil {
+		slog.Error("Error validating bulk request", "error", err)
+		c.Error(requestFailed(ErrFailedToInsert+"could not validate the request.", err))
+		return
+	}
+	result.Atomic = atomic
+
+	if atomic {
+		h.insertAtomic(c, validCodes, &result)
+		return
+	}
+
+	var pending []int
+	for i := range result.Results {
+		if result.Results[i].Status != models.BulkStatusRejected {
+			pending = append(pending, i)
+		}
+	}
+	for start := 0; start < len(pending); start += bulkInsertBatch {
+		h.insertBatch(c.Request.Context(), pending[start:min(start+bulkInsertBatch, len(pending))], validCodes, &result)
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// insertBatch inserts the codes at indexes in one transaction. When that fails,
+// they are inserted one by one, so that a failing code only rejects itself.
+func (h *SwiftCodeHandler) insertBatch(ctx context.Context, indexes []int, validCodes []models.SwiftCode, result *models.BulkResult) {
+	batch := make([]*models.SwiftCode, len(indexes))
+	for j, i := range indexes {
+		batch[j] = &validCodes[i]
+	}
+	err := h.service.AddSwiftCodes(ctx, batch)
+	if err != nil {
+		slog.Info("Error inserting bulk batch, inserting its codes one by one", "error", err)
+	}
+
+	for _, i := range indexes {
+		item := &result.Results[i]
+		if err != nil {
+			if err := h.service.AddSwiftCode(ctx, &validCodes[i]); err != nil {
+				slog.Info("Error inserting new code", "error", err)
+				item.Status = models.BulkStatusRejected
+				item.Message = ErrFailedToInsert + item.SwiftCode
+				if errors.Is(err, models.ErrAlreadyExists) {
+					item.Message = ErrFailedToInsert + ErrSwiftCodeExists
+				}
+				result.Rejected++
+				continue
+			}
+		}
+		item.Status = models.BulkStatusCreated
+		item.Message = addedMessage(item.SwiftCode)
+		result.Created++
+	}
+}
+
+func (h *SwiftCodeHandler) insertAtomic(c *gin.Context, validCodes []models.SwiftCode, result *models.BulkResult) {
+	if result.Rejected > 0 {
+		for i := range result.Results {
+			if result.Results[i].Status != models.BulkStatusRejected {
+				result.Results[i].Status = models.BulkStatusSkipped
+				result.Results[i].Message = ErrBatchNotInserted
+			}
+		}
+		c.JSON(http.StatusBadRequest, result)
+		return
+	}
+
+	newCodes := make([]*models.SwiftCode, len(validCodes))
+	for i := range validCodes {
+		newCodes[i] = &validCodes[i]
+	}
+
+	if err := h.service.AddSwiftCodes(c.Request.Context(), newCodes); err != nil {
+		slog.Error("Error inserting bulk codes", "error", err)
+		c.Error(requestFailed(ErrFailedToInsert+"no SWIFT codes have been added.", err))
+		return
+	}
+
+	for i := range result.Results {
+		result.Results[i].Status = models.BulkStatusCreated
+		result.Results[i].Message = addedMessage(result.Results[i].SwiftCode)
+	}
+	result.Created = len(newCodes)
+	c.JSON(http.StatusOK, result)
+}
+
+// validateBulk runs the checks of AddNewSwiftCode on every code. Rejected codes
+// get their final status, the others are left with an empty one and the code
+// to store at the same index of the returned codes.
+func (h *SwiftCodeHandler) validateBulk(ctx context.Context, newCodes []models.SwiftCodeBranch) (models.BulkResult, []models.SwiftCode, error) {
+	result := models.BulkResult{Results: make([]models.BulkItemResult, len(newCodes))}
+	seen := make(map[string]bool)
+	var candidates []string
+	var candidateIndexes []int
+
+	reject := func(i int, message string) {
+		result.Results[i].Status = models.BulkStatusRejected
+		result.Results[i].Message = message
+		result.Rejected++
+	}
+
+	validCodes, errs := h.service.ValidateNewSwiftCodes(ctx, newCodes)
+	if ctx.Err() != nil {
+		return result, nil, ctx.Err()
+	}
+
+	for i, newCode := range newCodes {
+		result.Results[i] = models.BulkItemResult{Index: i, SwiftCode: newCode.SwiftCode}
+
+		switch err := errs[i]; {
+		case errors.Is(err, models.ErrValidation):
+			err = firstError(err)
+			reject(i, ErrFailedToInsert+err.Error())
+			var fieldErr *validation.FieldError
+			if errors.As(err, &fieldErr) {
+				result.Results[i].Field = fieldErr.Field
+			}
+			continue
+		case errors.Is(err, models.ErrConflict):
+			reject(i, ErrFailedToInsert+ErrCountryMismatch)
+			continue
+		case err != nil:
+			slog.Error("Error checking country name from iso2", "iso2", newCode.CountryISO2, "error", err)
+			reject(i, ErrFailedToInsert+ErrUnknownISO2)
+			continue
+		}
+
+		if seen[newCode.SwiftCode] {
+			reject(i, ErrFailedToInsert+ErrSwiftCodeRepeated)
+			continue
+		}
+		seen[newCode.SwiftCode] = true
+
+		candidates = append(candidates, newCode.SwiftCode)
+		candidateIndexes = append(candidateIndexes, i)
+	}
+
+	if len(candidates) == 0 {
+		return result, validCodes, nil
+	}
+
+	existing, err := h.service.LookupSwiftCodes(ctx, candidates)
+	if err != nil {
+		return result, nil, err
+	}
+	for j, lookup := range existing {
+		if lookup.Found {
+			reject(candidateIndexes[j], ErrFailedToInsert+ErrSwiftCodeExists)
+		}
+	}
+
+	return result, validCodes, nil
+}
+
+func readBulkBody(c *gin.Context) ([]models.SwiftCodeBranch, error) {
+	if c.ContentType() != ndjsonContentType {
+		var newCodes []models.SwiftCodeBranch
+		if err := c.ShouldBindJSON(&newCodes); err != nil {
+			return nil, err
+		}
+		return newCodes, nil
+	}
+
+	var newCodes []models.SwiftCodeBranch
+	scanner := bufio.NewScanner(c.Request.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		if len(newCodes) == MaxBulkBatch {
+			return nil, errors.New("more than " + strconv.Itoa(MaxBulkBatch) + " SWIFT codes given.")
+		}
+		var newCode models.SwiftCodeBranch
+		if err := json.Unmarshal([]byte(text), &newCode); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		newCodes = append(newCodes, newCode)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return newCodes, nil
+}
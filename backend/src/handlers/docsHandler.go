@@ -0,0 +1,25 @@
+package handlers
+
+import (
+	"RemitlyTask/src/openapi"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files/v2"
+)
+
+// OpenAPISpec serves the OpenAPI document of the API.
+func OpenAPISpec(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json", openapi.Spec)
+}
+
+// SwaggerUI serves the bundled Swagger UI page at /docs/ and its assets,
+// configured to display the OpenAPI document.
+func SwaggerUI(c *gin.Context) {
+	file := c.Param("file")
+	if file == "/swagger-initializer.js" {
+		c.Data(http.StatusOK, "text/javascript; charset=utf-8", openapi.SwaggerInitializer)
+		return
+	}
+	c.FileFromFS(file, http.FS(swaggerFiles.FS))
+}
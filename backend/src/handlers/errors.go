@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"RemitlyTask/src/models"
+	"RemitlyTask/src/repositories"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// requestError is the error a handler fails a request with. message is shown
+// to the client, err decides the status code of the response.
+type requestError struct {
+	message string
+	err     error
+}
+
+func (e *requestError) Error() string {
+	if e.err == nil {
+		return e.message
+	}
+	return e.message + ": " + e.err.Error()
+}
+
+func (e *requestError) Unwrap() error {
+	return e.err
+}
+
+func requestFailed(message string, err error) error {
+	return &requestError{message: message, err: err}
+}
+
+// invalidRequest fails a request because of its parameters or body. err is the
+// cause, if there is one.
+func invalidRequest(message string, err error) error {
+	switch {
+	case err == nil:
+		err = models.ErrValidation
+	case !errors.Is(err, models.ErrValidation):
+		err = fmt.Errorf("%w: %w", models.ErrValidation, err)
+	}
+	return &requestError{message: message, err: err}
+}
+
+// firstError is the first of the errors joined into err, or err itself.
+func firstError(err error) error {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok && len(joined.Unwrap()) > 0 {
+		return joined.Unwrap()[0]
+	}
+	return err
+}
+
+var problemTitles = map[string]string{
+	models.ErrorCodeValidation:    "Invalid request",
+	models.ErrorCodeNotFound:      "Resource not found",
+	models.ErrorCodeAlreadyExists: "SWIFT code already exists",
+	models.ErrorCodeConflict:      "Conflicting SWIFT code data",
+	models.ErrorCodeReadOnly:      "Read-only mode",
+	models.ErrorCodeTimeout:       "Request timed out",
+	models.ErrorCodeInternal:      "Internal server error",
+}
+
+// errorStatus maps the domain error wrapped by err to the status code and the
+// machine-readable code of the response.
+func errorStatus(ctx context.Context, err error) (int, string) {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout, models.ErrorCodeTimeout
+	case errors.Is(err, models.ErrValidation):
+		return http.StatusBadRequest, models.ErrorCodeValidation
+	case errors.Is(err, models.ErrNotFound):
+		return http.StatusNotFound, models.ErrorCodeNotFound
+	case errors.Is(err, models.ErrAlreadyExists):
+		return http.StatusConflict, models.ErrorCodeAlreadyExists
+	case errors.Is(err, models.ErrConflict):
+		return http.StatusConflict, models.ErrorCodeConflict
+	case errors.Is(err, repositories.ErrReadOnly):
+		return http.StatusMethodNotAllowed, models.ErrorCodeReadOnly
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return http.StatusGatewayTimeout, models.ErrorCodeTimeout
+	}
+	return http.StatusInternalServerError, models.ErrorCodeInternal
+}
+
+// errorMessage is the message shown for err. Timeouts get the same message
+// whichever request they cut short.
+func errorMessage(err error, code string) string {
+	if code == models.ErrorCodeTimeout {
+		return ErrRequestTimeout
+	}
+	var reqErr *requestError
+	if errors.As(err, &reqErr) {
+		return reqErr.message
+	}
+	if code == models.ErrorCodeInternal {
+		return http.StatusText(http.StatusInternalServerError)
+	}
+	return err.Error()
+}
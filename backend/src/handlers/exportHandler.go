@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"RemitlyTask/src/export"
+	"RemitlyTask/src/models"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ExportCodes streams the directory in the requested format. The response is
+// only started once the first code has been read, so a failing query still
+// gets a 500; failures after that can only cut the response short. The request
+// timeout and the server's write timeout only bound the time until the first
+// code, since a large export may take longer to stream; a client that
+// disconnects still stops it.
+func (h *SwiftCodeHandler) ExportCodes(c *gin.Context) {
+	format := strings.ToLower(c.DefaultQuery("format", export.FormatCSV))
+	if format != export.FormatCSV && format != export.FormatJSON && format != export.FormatNDJSON {
+		c.Error(invalidRequest(ErrInvalidExport+"format must be csv, json or ndjson.", nil))
+		return
+	}
+
+	iso2 := strings.ToUpper(c.Query("country"))
+	if iso2 != "" {
+		if err := validateISO2(iso2); err != nil {
+			c.Error(err)
+			return
+		}
+	}
+
+	writer, err := export.NewWriter(format, c.Writer)
+	if err != nil {
+		c.Error(invalidRequest(ErrInvalidExport+err.Error(), err))
+		return
+	}
+
+	reqCtx := c.Request.Context()
+	ctx, cancel := context.WithCancelCause(context.WithoutCancel(reqCtx))
+	defer cancel(nil)
+	var streaming atomic.Bool
+	stop := context.AfterFunc(reqCtx, func() {
+		if err := reqCtx.Err(); !streaming.Load() || !errors.Is(err, context.DeadlineExceeded) {
+			cancel(err)
+		}
+	})
+	defer stop()
+
+	started := false
+	start := func() {
+		started = true
+		// A writer that doesn't support deadlines has none to clear.
+		_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+		c.Header("Content-Type", export.ContentType(format))
+		c.Header("Content-Disposition", `attachment; filename="`+exportFileName(iso2, format)+`"`)
+		c.Status(http.StatusOK)
+	}
+
+	err = h.service.ExportSwiftCodes(ctx, iso2, func(code models.SwiftCode) error {
+		if !started {
+			streaming.Store(true)
+			if ctx.Err() != nil {
+				return context.Cause(ctx)
+			}
+			start()
+		}
+		return writer.Write(code)
+	})
+	if err != nil && !started && ctx.Err() != nil {
+		err = context.Cause(ctx)
+	}
+	if err != nil {
+		slog.Error("Error exporting swift codes", "error", err)
+		if !started {
+			c.Error(requestFailed(ErrFailedToExport, err))
+			return
+		}
+		c.Abort()
+		return
+	}
+
+	if !started {
+		start()
+	}
+	if err := writer.Close(); err != nil {
+		slog.Error("Error exporting swift codes", "error", err)
+	}
+}
+
+func exportFileName(iso2, format string) string {
+	if iso2 == "" {
+		return "swift-codes." + format
+	}
+	return "swift-codes-" + iso2 + "." + format
+}
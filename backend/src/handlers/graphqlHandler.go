@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type graphQLRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// GraphQL runs a query sent as a JSON body, or in the query, operationName and
+// variables parameters of a GET. Errors of the query itself are listed in the
+// errors member of a 200 response, as GraphQL clients expect; only requests
+// that carry no query at all are answered with a problem document.
+func (h *SwiftCodeHandler) GraphQL(c *gin.Context) {
+	var request graphQLRequest
+	if c.Request.Method == http.MethodGet {
+		request.Query = c.Query("query")
+		request.OperationName = c.Query("operationName")
+		if variables := c.Query("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &request.Variables); err != nil {
+				c.Error(invalidRequest(ErrInvalidGraphQL+"variables must be a JSON object.", err))
+				return
+			}
+		}
+	} else if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(invalidRequest(ErrInvalidGraphQL+err.Error(), err))
+		return
+	}
+
+	if request.Query == "" {
+		c.Error(invalidRequest(ErrInvalidGraphQL+"query is required.", nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.graph.Exec(c.Request.Context(), request.Query, request.OperationName, request.Variables))
+}
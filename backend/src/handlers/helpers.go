@@ -1,6 +1,16 @@
 package handlers
 
 import (
+	"RemitlyTask/src/models"
+	"RemitlyTask/src/repositories"
+	"RemitlyTask/src/validation"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
 	"github.com/gin-gonic/gin"
 )
 
@@ -8,24 +18,186 @@ func parseSwiftCode(swiftCode string) (prefix, suffix string) {
 	return swiftCode[:len(swiftCode)-3], swiftCode[len(swiftCode)-3:]
 }
 
-func isValidSwiftCode(swiftCode string) bool {
-	return len(swiftCode) == 8 || len(swiftCode) == 11
-}
-
-func validateSwiftCode(swiftCode string) (bool, *gin.H) {
-	if !isValidSwiftCode(swiftCode) {
-		return false, &gin.H{"message": ErrInvalidSwiftLength}
+func validateSwiftCode(swiftCode string) error {
+	if _, err := validation.ParseBIC(swiftCode); err != nil {
+		return invalidRequest(ErrInvalidSwiftCode+err.Error(), err)
 	}
-	return true, nil
+	return nil
 }
 
 func isISO2Valid(iso2Code string) bool {
 	return len(iso2Code) == 2
 }
 
-func validateISO2(iso2Code string) (bool, *gin.H) {
+func validateISO2(iso2Code string) error {
 	if !isISO2Valid(iso2Code) {
-		return false, &gin.H{"message": ErrInvalidISO2Length}
+		return invalidRequest(ErrInvalidISO2Length, &validation.FieldError{Field: validation.FieldCountryISO2, Value: iso2Code, Err: validation.ErrInvalidISO2})
+	}
+	return nil
+}
+
+// parseMergePatch decodes an RFC 7396 JSON merge patch. A null member clears
+// the field, absent members leave it unchanged. PUT bodies are decoded the same
+// way, so that both reject the members that can't be updated.
+func parseMergePatch(body []byte) (models.SwiftCodePatch, error) {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(body, &members); err != nil {
+		return models.SwiftCodePatch{}, err
+	}
+	if members == nil {
+		return models.SwiftCodePatch{}, errors.New("patch must be a JSON object")
+	}
+
+	var patch models.SwiftCodePatch
+	fields := map[string]**string{
+		validation.FieldAddress:     &patch.Address,
+		validation.FieldBankName:    &patch.BankName,
+		validation.FieldTownName:    &patch.TownName,
+		validation.FieldTimeZone:    &patch.TimeZone,
+		validation.FieldSwiftCode:   &patch.SwiftCode,
+		validation.FieldCountryISO2: &patch.CountryISO2,
+	}
+
+	for name, raw := range members {
+		field, ok := fields[name]
+		if !ok {
+			return models.SwiftCodePatch{}, &validation.FieldError{Field: name, Value: string(raw), Err: validation.ErrUnknownField}
+		}
+
+		value := ""
+		if string(raw) != "null" {
+			if err := json.Unmarshal(raw, &value); err != nil {
+				return models.SwiftCodePatch{}, fmt.Errorf("%s must be a string or null", name)
+			}
+		}
+		*field = &value
+	}
+
+	return patch, nil
+}
+
+// replacementPatch turns a PUT body into a patch that clears every field the
+// body leaves out.
+func replacementPatch(patch models.SwiftCodePatch) models.SwiftCodePatch {
+	for _, field := range []**string{&patch.Address, &patch.BankName, &patch.TownName, &patch.TimeZone} {
+		if *field == nil {
+			empty := ""
+			*field = &empty
+		}
+	}
+	return patch
+}
+
+const (
+	DefaultPageSize      = 100
+	MaxPageSize          = 1000
+	DefaultSearchLimit   = 20
+	MaxSearchLimit       = 100
+	MinSearchQueryLength = 2
+	DefaultSuggestLimit  = 10
+	MaxSuggestLimit      = 50
+	MinSuggestPrefix     = 3
+	MaxLookupBatch       = 1000
+	MaxBulkBatch         = 1000
+)
+
+func parseLimit(c *gin.Context, defaultLimit, maxLimit int) (int, error) {
+	limit := c.Query("limit")
+	if limit == "" {
+		return defaultLimit, nil
+	}
+	value, err := strconv.Atoi(limit)
+	if err != nil || value < 1 || value > maxLimit {
+		return 0, invalidRequest(ErrInvalidQuery+"limit must be a number between 1 and "+strconv.Itoa(maxLimit)+".", nil)
+	}
+	return value, nil
+}
+
+func parseCountryQuery(c *gin.Context) (models.CountryQuery, error) {
+	query := models.CountryQuery{
+		SortBy: c.DefaultQuery("sort", models.SortBySwiftCode),
+		Town:   c.Query("town"),
 	}
-	return true, nil
+
+	limit, err := parseLimit(c, DefaultPageSize, MaxPageSize)
+	if err != nil {
+		return query, err
+	}
+	query.Limit = limit
+
+	switch query.SortBy {
+	case models.SortBySwiftCode, models.SortByBankName, models.SortByTownName:
+	default:
+		return query, invalidRequest(ErrInvalidQuery+"sort must be one of swiftCode, bankName or townName.", nil)
+	}
+
+	switch c.DefaultQuery("order", "asc") {
+	case "asc":
+	case "desc":
+		query.Descending = true
+	default:
+		return query, invalidRequest(ErrInvalidQuery+"order must be asc or desc.", nil)
+	}
+
+	if isHeadquarter := c.Query("isHeadquarter"); isHeadquarter != "" {
+		value, err := strconv.ParseBool(isHeadquarter)
+		if err != nil {
+			return query, invalidRequest(ErrInvalidQuery+"isHeadquarter must be true or false.", nil)
+		}
+		query.IsHeadquarter = &value
+	}
+
+	if cursor := c.Query("cursor"); cursor != "" {
+		after, err := models.DecodeCursor(cursor)
+		if err != nil || after.SortBy != query.SortBy || after.Descending != query.Descending {
+			return query, invalidRequest(ErrInvalidQuery+"cursor is not valid for this sort order.", nil)
+		}
+		query.After = &after
+	}
+
+	return query, nil
+}
+
+// readContext is the context the reads of a request run with. It includes
+// deleted codes when the includeDeleted query parameter is true, and reads the
+// directory as it was at the time of the asOf query parameter, if given.
+func readContext(c *gin.Context) (context.Context, error) {
+	ctx := c.Request.Context()
+
+	include := false
+	if includeDeleted := c.Query("includeDeleted"); includeDeleted != "" {
+		var err error
+		if include, err = strconv.ParseBool(includeDeleted); err != nil {
+			return ctx, invalidRequest(ErrInvalidQuery+"includeDeleted must be true or false.", nil)
+		}
+	}
+
+	if asOf := c.Query("asOf"); asOf != "" {
+		t, err := parseAsOf(asOf)
+		if err != nil {
+			return ctx, invalidRequest(ErrInvalidQuery+"asOf must be a date such as 2026-07-01 or an RFC 3339 time.", nil)
+		}
+		if include {
+			return ctx, invalidRequest(ErrInvalidQuery+"asOf can't be combined with includeDeleted.", nil)
+		}
+		return repositories.AsOf(ctx, t), nil
+	}
+
+	if include {
+		ctx = repositories.WithDeleted(ctx)
+	}
+	return ctx, nil
+}
+
+// parseAsOf reads an RFC 3339 time, or a date, which stands for its start in
+// UTC.
+func parseAsOf(asOf string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, asOf); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, asOf)
+}
+
+func addedMessage(swiftCode string) string {
+	return swiftCode + " has been added to the database."
 }
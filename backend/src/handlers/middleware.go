@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"RemitlyTask/src/models"
+	"RemitlyTask/src/proxy"
+	"RemitlyTask/src/repositories"
+	"RemitlyTask/src/validation"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReadOnly rejects the requests of the routes it guards with 405. The Allow
+// header lists the read methods registered on r for the same path.
+func ReadOnly(r *gin.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var allowed []string
+		for _, route := range r.Routes() {
+			if route.Path == c.FullPath() && (route.Method == http.MethodGet || route.Method == http.MethodHead) {
+				allowed = append(allowed, route.Method)
+			}
+		}
+		c.Writer.Header().Set("Allow", strings.Join(allowed, ", "))
+		c.Error(requestFailed(ErrReadOnly, repositories.ErrReadOnly))
+		c.Abort()
+	}
+}
+
+const (
+	HeaderActor     = "X-Actor"
+	HeaderRequestID = "X-Request-ID"
+)
+
+// Actor attributes the writes of a request to the caller named by the X-Actor
+// header, or else to the client IP, when the request comes from one of the
+// trusted proxies, which authenticate callers. Other requests are attributed
+// to their remote address, and their X-Actor header is only recorded as the
+// claimed actor. The request ID is taken from X-Request-ID, or generated, and
+// echoed in the response so it can be matched with the audit log.
+func Actor(trusted proxy.Trusted) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actor := repositories.Actor{Name: c.Request.RemoteAddr, Claimed: c.GetHeader(HeaderActor)}
+		if trusted.Contains(c.Request.RemoteAddr) {
+			actor = repositories.Actor{Name: c.GetHeader(HeaderActor)}
+			if actor.Name == "" {
+				actor.Name = c.ClientIP()
+			}
+		}
+		requestID := c.GetHeader(HeaderRequestID)
+		if requestID == "" {
+			requestID = newRequestID()
+		}
+		c.Header(HeaderRequestID, requestID)
+		actor.RequestID = requestID
+		c.Request = c.Request.WithContext(repositories.WithActor(c.Request.Context(), actor))
+		c.Next()
+	}
+}
+
+func newRequestID() string {
+	id := make([]byte, 16)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// Timeout bounds the context of every request, so the queries it runs are
+// cancelled once timeout has passed. A zero timeout leaves requests unbounded.
+func Timeout(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if timeout <= 0 {
+			c.Next()
+			return
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// ErrorHandler writes the response of a request that a handler failed with
+// c.Error as an RFC 7807 problem document. The status code follows from the
+// domain error it wraps, so every handler reports the same failure the same
+// way. legacyMessage also sets the message and field members of the earlier
+// error format.
+func ErrorHandler(legacyMessage bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		err := c.Errors.Last().Err
+		status, code := errorStatus(c.Request.Context(), err)
+		problem := models.Problem{
+			Type:     models.ProblemType(code),
+			Title:    problemTitles[code],
+			Status:   status,
+			Detail:   errorMessage(err, code),
+			Instance: c.Request.URL.RequestURI(),
+			Code:     code,
+		}
+		for _, fieldErr := range validation.FieldErrors(err) {
+			problem.Errors = append(problem.Errors, models.FieldProblem{
+				Field:  fieldErr.Field,
+				Value:  fieldErr.Value,
+				Detail: fieldErr.Err.Error(),
+			})
+		}
+		if legacyMessage {
+			problem.Message = problem.Detail
+			if len(problem.Errors) > 0 {
+				problem.Field = problem.Errors[0].Field
+			}
+		}
+
+		c.Writer.Header().Set("Content-Type", models.ProblemContentType)
+		c.JSON(status, problem)
+	}
+}
@@ -1,11 +1,14 @@
 package handlers
 
 import (
+	"RemitlyTask/src/graph"
 	"RemitlyTask/src/models"
 	"RemitlyTask/src/repositories"
 	"RemitlyTask/src/services"
-	"log"
+	"errors"
+	"log/slog"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -14,170 +17,358 @@ import (
 
 type SwiftCodeHandler struct {
 	service services.ISwiftCodeService
+	graph   *graph.Schema
 }
 
 func NewSwiftCodeHandler(db *gorm.DB) *SwiftCodeHandler {
 	repo := repositories.NewSwiftCodeRepository(db)
 	service := services.NewSwiftCodeService(repo)
-	return &SwiftCodeHandler{service: service}
+	return NewSwiftCodeHandlerByService(service)
 }
 
 func NewSwiftCodeHandlerByService(service services.ISwiftCodeService) *SwiftCodeHandler {
-	return &SwiftCodeHandler{service: service}
+	return &SwiftCodeHandler{service: service, graph: graph.NewSchema(service)}
 }
 
 func (h *SwiftCodeHandler) GetCode(c *gin.Context) {
 	swiftCodeParam := c.Param("swift-code")
 
-	if valid, response := validateSwiftCode(swiftCodeParam); !valid {
-		c.JSON(http.StatusBadRequest, response)
+	if err := validateSwiftCode(swiftCodeParam); err != nil {
+		c.Error(err)
 		return
+	}
 
+	ctx, err := readContext(c)
+	if err != nil {
+		c.Error(err)
+		return
 	}
 
+	var response interface{}
 	swiftCodePrefix, swiftCodeSuffix := parseSwiftCode(swiftCodeParam)
 	if swiftCodeSuffix == "XXX" {
-		response, err := h.service.GetHeadquarterDetails(swiftCodePrefix)
-		if err != nil {
-			log.Println(ErrFetchSwiftCodes, "for: ", swiftCodeParam, err)
-			c.JSON(http.StatusInternalServerError, gin.H{"message": ErrFetchSwiftCodes + "for: " + swiftCodeParam})
-			return
-		}
+		response, err = h.service.GetHeadquarterDetails(ctx, swiftCodePrefix)
+	} else {
+		response, err = h.service.GetBranchDetails(ctx, swiftCodeParam)
+	}
 
-		if response == nil {
-			c.JSON(http.StatusNotFound, gin.H{"message": ErrNoSwiftCodeFound + "for: " + swiftCodeParam})
-			return
-		}
+	if errors.Is(err, models.ErrNotFound) {
+		c.Error(requestFailed(ErrNoSwiftCodeFound+"for: "+swiftCodeParam, err))
+		return
+	}
+	if err != nil {
+		slog.Error("Error fetching swift code", "swiftCode", swiftCodeParam, "error", err)
+		c.Error(requestFailed(ErrFetchSwiftCodes+"for: "+swiftCodeParam, err))
+		return
+	}
 
-		c.JSON(http.StatusOK, response)
+	c.JSON(http.StatusOK, response)
+}
 
-	} else {
-		response, err := h.service.GetBranchDetails(swiftCodeParam)
-		if err != nil {
-			log.Println(ErrFetchSwiftCodes, "for: ", swiftCodeParam, err)
-			c.JSON(http.StatusInternalServerError, gin.H{"message": ErrFetchSwiftCodes + "for: " + swiftCodeParam})
-			return
-		}
+func (h *SwiftCodeHandler) LookupCodes(c *gin.Context) {
+	var swiftCodes []string
+	if err := c.ShouldBindJSON(&swiftCodes); err != nil {
+		slog.Info("Error binding JSON", "error", err)
+		c.Error(invalidRequest(ErrInvalidLookup+err.Error(), err))
+		return
+	}
 
-		if response == nil {
-			c.JSON(http.StatusNotFound, gin.H{"message": ErrNoSwiftCodeFound + "for: " + swiftCodeParam})
-			return
-		}
+	if len(swiftCodes) == 0 || len(swiftCodes) > MaxLookupBatch {
+		c.Error(invalidRequest(ErrInvalidLookup+"between 1 and "+strconv.Itoa(MaxLookupBatch)+" SWIFT codes must be given.", nil))
+		return
+	}
 
-		c.JSON(http.StatusOK, response)
+	results, err := h.service.LookupSwiftCodes(c.Request.Context(), swiftCodes)
+	if err != nil {
+		slog.Error("Error looking up swift codes", "error", err)
+		c.Error(requestFailed(ErrFetchSwiftCodes+"for lookup.", err))
+		return
 	}
+
+	c.JSON(http.StatusOK, models.SwiftCodeLookup{Results: results})
 }
 
 func (h *SwiftCodeHandler) GetCodesByCountry(c *gin.Context) {
 	iso2 := strings.ToUpper(c.Param("ISO2"))
 
-	if valid, response := validateISO2(iso2); !valid {
-		c.JSON(http.StatusBadRequest, response)
+	if err := validateISO2(iso2); err != nil {
+		c.Error(err)
 		return
 	}
 
-	response, err := h.service.GetSwiftCodesByCountry(iso2)
+	query, err := parseCountryQuery(c)
 	if err != nil {
-		log.Println("Error fetching swift codes:", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"message": ErrFetchSwiftCodes + "for ISO2 code: " + iso2})
+		c.Error(err)
+		return
+	}
+
+	ctx, err := readContext(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response, err := h.service.GetSwiftCodesByCountry(ctx, iso2, query)
+	if errors.Is(err, models.ErrNotFound) {
+		c.Error(requestFailed(ErrNoSwiftCodeFound+"for ISO2 code: "+iso2, err))
+		return
+	}
+	if err != nil {
+		slog.Error("Error fetching swift codes", "iso2", iso2, "error", err)
+		c.Error(requestFailed(ErrFetchSwiftCodes+"for ISO2 code: "+iso2, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *SwiftCodeHandler) SearchCodes(c *gin.Context) {
+	query := strings.TrimSpace(c.Query("q"))
+	if len(query) < MinSearchQueryLength {
+		c.Error(invalidRequest(ErrInvalidQuery+"q must be at least "+strconv.Itoa(MinSearchQueryLength)+" characters long.", nil))
+		return
+	}
+
+	iso2 := strings.ToUpper(c.Query("country"))
+	if iso2 != "" {
+		if err := validateISO2(iso2); err != nil {
+			c.Error(err)
+			return
+		}
+	}
+
+	limit, err := parseLimit(c, DefaultSearchLimit, MaxSearchLimit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	results, err := h.service.SearchSwiftCodes(c.Request.Context(), query, iso2, limit)
+	if err != nil {
+		slog.Error("Error searching swift codes", "error", err)
+		c.Error(requestFailed(ErrFetchSwiftCodes+"for query: "+query, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SwiftCodeSearch{Query: query, Results: results})
+}
+
+func (h *SwiftCodeHandler) SuggestCodes(c *gin.Context) {
+	prefix := strings.TrimSpace(c.Query("prefix"))
+	if len(prefix) < MinSuggestPrefix {
+		c.Error(invalidRequest(ErrInvalidQuery+"prefix must be at least "+strconv.Itoa(MinSuggestPrefix)+" characters long.", nil))
 		return
 	}
 
-	SwiftCodeCountry, ok := response.(models.SwiftCodeCountry)
-	if !ok {
-		log.Println("Invalid response type")
-		c.JSON(http.StatusInternalServerError, gin.H{"message": "Invalid response type"})
+	limit, err := parseLimit(c, DefaultSuggestLimit, MaxSuggestLimit)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
-	if SwiftCodeCountry.CountryName == "" {
-		log.Println("ISO2 code: " + iso2 + " is not valid.")
-		c.JSON(http.StatusInternalServerError, gin.H{"message": "ISO2 code " + iso2 + " is not valid."})
+	suggestions, err := h.service.SuggestSwiftCodes(c.Request.Context(), prefix, limit)
+	if err != nil {
+		slog.Error("Error suggesting swift codes", "error", err)
+		c.Error(requestFailed(ErrFetchSwiftCodes+"for prefix: "+prefix, err))
 		return
 	}
 
-	c.JSON(http.StatusOK, SwiftCodeCountry)
+	c.JSON(http.StatusOK, models.SwiftCodeSuggestions{Prefix: prefix, Suggestions: suggestions})
 }
 
 func (h *SwiftCodeHandler) AddNewSwiftCode(c *gin.Context) {
 	var newSwiftCode models.SwiftCodeBranch
 
 	if err := c.ShouldBindJSON(&newSwiftCode); err != nil {
-		log.Println("Error binding JSON: ", err)
-		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		slog.Info("Error binding JSON", "error", err)
+		c.Error(invalidRequest(err.Error(), err))
 		return
 	}
 
-	if len(newSwiftCode.CountryISO2) != 2 {
-		log.Println("Error inserting new code: this iso2 code does not exist.")
-		c.JSON(http.StatusBadRequest, gin.H{"message": ErrFailedToInsert + "this iso2 code: " + newSwiftCode.CountryISO2 + " does not exist."})
+	newValidatedCode, err := h.service.ValidateNewSwiftCode(c.Request.Context(), newSwiftCode)
+	switch {
+	case errors.Is(err, models.ErrValidation):
+		slog.Info("Error inserting new code", "error", err)
+		c.Error(invalidRequest(ErrFailedToInsert+firstError(err).Error(), err))
+		return
+	case errors.Is(err, models.ErrConflict):
+		slog.Info("Error inserting new code: iso2 code must match with given country.")
+		c.Error(requestFailed(ErrFailedToInsert+ErrCountryMismatch, err))
+		return
+	case err != nil:
+		slog.Error("Error checking country name from iso2", "error", err)
+		c.Error(requestFailed(ErrFailedToInsert+newSwiftCode.SwiftCode, err))
 		return
 	}
 
-	if len(newSwiftCode.SwiftCode) != 8 && len(newSwiftCode.SwiftCode) != 11 {
-		log.Println("Error inserting new code: invalid swift code length")
-		c.JSON(http.StatusBadRequest, gin.H{"message": ErrFailedToInsert + newSwiftCode.SwiftCode + " swift code must be 8 or 11 characters long."})
+	err = h.service.AddSwiftCode(c.Request.Context(), &newValidatedCode)
+	if errors.Is(err, models.ErrAlreadyExists) {
+		slog.Info("Error inserting new code: swift code already exists")
+		c.Error(requestFailed(ErrFailedToInsert+ErrSwiftCodeExists, err))
+		return
+	}
+	if err != nil {
+		slog.Error("Error inserting new code", "error", err)
+		c.Error(requestFailed(ErrFailedToInsert+newSwiftCode.SwiftCode, err))
 		return
 	}
+	c.JSON(http.StatusOK, gin.H{"message": addedMessage(newSwiftCode.SwiftCode)})
+}
+
+func (h *SwiftCodeHandler) ReplaceCode(c *gin.Context) {
+	swiftCode := c.Param("swift-code")
 
-	if (!strings.HasSuffix(newSwiftCode.SwiftCode, "XXX") && newSwiftCode.IsHeadquarter) || (strings.HasSuffix(newSwiftCode.SwiftCode, "XXX") && !newSwiftCode.IsHeadquarter) {
-		log.Println("Error inserting new code: isHeadquarter does not match the suffix of swiftcode.")
-		c.JSON(http.StatusBadRequest, gin.H{"message": ErrFailedToInsert + "isHeadquarter does not match the suffix of swiftcode."})
+	if err := validateSwiftCode(swiftCode); err != nil {
+		c.Error(err)
 		return
 	}
 
-	if newSwiftCode.Address == "" {
-		log.Println("Error inserting new code: address can't be empty")
-		c.JSON(http.StatusBadRequest, gin.H{"message": ErrFailedToInsert + "address can't be empty."})
+	body, err := c.GetRawData()
+	if err != nil {
+		slog.Info("Error reading request body", "error", err)
+		c.Error(invalidRequest(err.Error(), err))
 		return
 	}
 
-	countryName, err := h.service.GetCountryName(newSwiftCode.CountryISO2)
+	replacement, err := parseMergePatch(body)
 	if err != nil {
-		log.Println("Error checking country name from iso2")
-		c.JSON(http.StatusBadRequest, gin.H{"message": ErrFailedToInsert + "invalid ISO2 code."})
+		slog.Info("Error parsing replacement", "error", err)
+		c.Error(invalidRequest(err.Error(), err))
+		return
+	}
+
+	h.updateCode(c, swiftCode, replacementPatch(replacement))
+}
+
+func (h *SwiftCodeHandler) PatchCode(c *gin.Context) {
+	swiftCode := c.Param("swift-code")
+
+	if err := validateSwiftCode(swiftCode); err != nil {
+		c.Error(err)
 		return
 	}
 
-	if !strings.EqualFold(newSwiftCode.CountryName, countryName) && countryName != "" {
-		log.Println("Error inserting new code: iso2 code must match with given country.")
-		c.JSON(http.StatusBadRequest, gin.H{"message": ErrFailedToInsert + "iso2 code must match with given country."})
+	body, err := c.GetRawData()
+	if err != nil {
+		slog.Info("Error reading request body", "error", err)
+		c.Error(invalidRequest(ErrInvalidPatch+err.Error(), err))
 		return
 	}
 
-	existingCode, err := h.service.GetBranchDetails(newSwiftCode.SwiftCode)
-	if err == nil && existingCode != nil {
-		log.Println("Error inserting new code: swift code already exists")
-		c.JSON(http.StatusBadRequest, gin.H{"message": ErrFailedToInsert + "swift code already exists"})
+	patch, err := parseMergePatch(body)
+	if err != nil {
+		slog.Info("Error parsing merge patch", "error", err)
+		c.Error(invalidRequest(ErrInvalidPatch+err.Error(), err))
 		return
 	}
 
-	newValidatedCode := models.SwiftCode{
-		Address:     newSwiftCode.Address,
-		Name:        newSwiftCode.BankName,
-		CountryISO2: newSwiftCode.CountryISO2,
-		SwiftCode:   newSwiftCode.SwiftCode,
-		CountryName: newSwiftCode.CountryName,
+	h.updateCode(c, swiftCode, patch)
+}
+
+func (h *SwiftCodeHandler) updateCode(c *gin.Context, swiftCode string, patch models.SwiftCodePatch) {
+	err := h.service.UpdateSwiftCode(c.Request.Context(), swiftCode, patch)
+
+	switch {
+	case err == nil:
+		c.JSON(http.StatusOK, gin.H{"message": swiftCode + " has been updated."})
+	case errors.Is(err, models.ErrNotFound):
+		c.Error(requestFailed(ErrNoSwiftCodeFound+"for: "+swiftCode, err))
+	case errors.Is(err, models.ErrValidation):
+		slog.Info("Error updating code", "swiftCode", swiftCode, "error", err)
+		c.Error(requestFailed(ErrFailedToUpdate+err.Error(), err))
+	default:
+		slog.Error("Error updating code", "swiftCode", swiftCode, "error", err)
+		c.Error(requestFailed(ErrFailedToUpdate+swiftCode, err))
 	}
+}
 
-	err = h.service.AddSwiftCode(&newValidatedCode)
+// GetCodeHistory lists the audited writes to a code, oldest first.
+func (h *SwiftCodeHandler) GetCodeHistory(c *gin.Context) {
+	swiftCode := c.Param("swift-code")
+
+	if err := validateSwiftCode(swiftCode); err != nil {
+		c.Error(err)
+		return
+	}
+
+	history, err := h.service.GetSwiftCodeHistory(c.Request.Context(), swiftCode)
 	if err != nil {
-		log.Println("Error inserting new code: ", err)
-		c.JSON(http.StatusBadRequest, gin.H{"message": ErrFailedToInsert + newSwiftCode.SwiftCode})
+		c.Error(requestFailed(ErrFetchHistory+swiftCode, err))
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"message": newSwiftCode.SwiftCode + " has been added to the database."})
+
+	c.JSON(http.StatusOK, history)
 }
 
+// DeleteCode deletes a code. Deleting a headquarter that still has branches is
+// refused unless cascade=true, which deletes the branches along with it.
 func (h *SwiftCodeHandler) DeleteCode(c *gin.Context) {
 	swiftCode := c.Param("swift-code")
 
-	err := h.service.DeleteSwiftCode(swiftCode)
-	if err != nil {
-		log.Printf("Error deleting code %s: %v", swiftCode, err)
-		c.JSON(http.StatusNotFound, gin.H{"message": ErrFailedToDelete + " " + err.Error()})
+	if err := validateSwiftCode(swiftCode); err != nil {
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": swiftCode + " was removed."})
+	cascade := false
+	if value := c.Query("cascade"); value != "" {
+		var err error
+		if cascade, err = strconv.ParseBool(value); err != nil {
+			c.Error(invalidRequest(ErrInvalidQuery+"cascade must be true or false.", nil))
+			return
+		}
+	}
+
+	var removed []string
+	var err error
+	if cascade {
+		removed, err = h.service.DeleteSwiftCodeCascade(c.Request.Context(), swiftCode)
+	} else {
+		err = h.service.DeleteSwiftCode(c.Request.Context(), swiftCode)
+	}
+	switch {
+	case err == nil:
+	case errors.Is(err, models.ErrNotFound):
+		c.Error(requestFailed(ErrFailedToDelete+" "+err.Error(), err))
+		return
+	case errors.Is(err, models.ErrConflict):
+		c.Error(requestFailed(ErrFailedToDelete+" "+swiftCode+": "+ErrHasBranches, err))
+		return
+	default:
+		slog.Error("Error deleting code", "swiftCode", swiftCode, "error", err)
+		c.Error(requestFailed(ErrFailedToDelete+" "+swiftCode, err))
+		return
+	}
+
+	if !cascade {
+		c.JSON(http.StatusOK, gin.H{"message": swiftCode + " was removed."})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": swiftCode + " was removed with its branches.",
+		"removed": removed,
+	})
+}
+
+// RestoreCode undoes the deletion of a code that hasn't been purged yet.
+func (h *SwiftCodeHandler) RestoreCode(c *gin.Context) {
+	swiftCode := c.Param("swift-code")
+
+	if err := validateSwiftCode(swiftCode); err != nil {
+		c.Error(err)
+		return
+	}
+
+	err := h.service.RestoreSwiftCode(c.Request.Context(), swiftCode)
+	switch {
+	case err == nil:
+		c.JSON(http.StatusOK, gin.H{"message": swiftCode + " has been restored."})
+	case errors.Is(err, models.ErrNotFound):
+		c.Error(requestFailed(ErrNoSwiftCodeFound+"for: "+swiftCode, err))
+	case errors.Is(err, models.ErrConflict):
+		c.Error(requestFailed(ErrFailedToRestore+swiftCode+" is not deleted.", err))
+	default:
+		slog.Error("Error restoring code", "swiftCode", swiftCode, "error", err)
+		c.Error(requestFailed(ErrFailedToRestore+swiftCode, err))
+	}
 }
@@ -0,0 +1,93 @@
+package importer
+
+import (
+	"RemitlyTask/src/models"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var Header = []string{"COUNTRY ISO2 CODE", "SWIFT CODE", "CODE TYPE", "NAME", "ADDRESS", "TOWN NAME", "COUNTRY NAME", "TIME ZONE"}
+
+type Row struct {
+	Line int
+	Code models.SwiftCode
+}
+
+// ReadRows parses a SWIFT directory file. Rows with a wrong number of fields
+// are reported as rejections, any other malformed input aborts the read.
+func ReadRows(r io.Reader) ([]Row, []RowResult, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = len(Header)
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading header: %w", err)
+	}
+	if err := checkHeader(header); err != nil {
+		return nil, nil, err
+	}
+
+	var rows []Row
+	var rejected []RowResult
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line, _ := reader.FieldPos(0)
+		if err != nil {
+			if errors.Is(err, csv.ErrFieldCount) {
+				rejection := RowResult{Line: line, Status: StatusRejected, Reason: err.Error()}
+				if len(record) > 1 {
+					rejection.SwiftCode = record[1]
+				}
+				rejected = append(rejected, rejection)
+				continue
+			}
+			return nil, nil, err
+		}
+
+		rows = append(rows, Row{
+			Line: line,
+			Code: models.SwiftCode{
+				CountryISO2: strings.ToUpper(record[0]),
+				SwiftCode:   record[1],
+				CodeType:    record[2],
+				Name:        record[3],
+				Address:     record[4],
+				TownName:    record[5],
+				CountryName: strings.ToUpper(record[6]),
+				TimeZone:    record[7],
+			},
+		})
+	}
+
+	return rows, rejected, nil
+}
+
+// Record is the row of a SWIFT directory file holding code, in the column
+// order of Header.
+func Record(code models.SwiftCode) []string {
+	return []string{
+		code.CountryISO2,
+		code.SwiftCode,
+		code.CodeType,
+		code.Name,
+		code.Address,
+		code.TownName,
+		code.CountryName,
+		code.TimeZone,
+	}
+}
+
+func checkHeader(header []string) error {
+	for i, column := range Header {
+		if strings.TrimSpace(strings.TrimPrefix(header[i], "\ufeff")) != column {
+			return fmt.Errorf("unexpected column %d: got %q, want %q", i+1, header[i], column)
+		}
+	}
+	return nil
+}
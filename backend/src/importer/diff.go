@@ -0,0 +1,155 @@
+package importer
+
+import (
+	"RemitlyTask/src/models"
+	"RemitlyTask/src/repositories"
+	"context"
+	"io"
+)
+
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+type ChangedRecord struct {
+	Line        int           `json:"line,omitempty"`
+	SwiftCode   string        `json:"swiftCode"`
+	CountryISO2 string        `json:"countryISO2"`
+	BankName    string        `json:"bankName"`
+	Address     string        `json:"address,omitempty"`
+	TownName    string        `json:"townName,omitempty"`
+	TimeZone    string        `json:"timeZone,omitempty"`
+	Changes     []FieldChange `json:"changes,omitempty"`
+}
+
+type ChangeReport struct {
+	DryRun   bool            `json:"dryRun"`
+	Added    []ChangedRecord `json:"added"`
+	Restored []ChangedRecord `json:"restored"`
+	Removed  []ChangedRecord `json:"removed"`
+	Modified []ChangedRecord `json:"modified"`
+	Rejected []RowResult     `json:"rejected"`
+}
+
+// Sync compares a directory release with the stored dataset. Deleted codes
+// that are listed again are restored with the data of the release. Unless
+// dryRun is set, the additions, restorations, modifications and removals are
+// applied in one transaction.
+func (i *Importer) Sync(ctx context.Context, r io.Reader, dryRun bool) (ChangeReport, error) {
+	rows, rejected, err := ReadRows(r)
+	if err != nil {
+		return ChangeReport{}, err
+	}
+
+	report := ChangeReport{
+		DryRun:   dryRun,
+		Added:    []ChangedRecord{},
+		Restored: []ChangedRecord{},
+		Removed:  []ChangedRecord{},
+		Modified: []ChangedRecord{},
+		Rejected: rejected,
+	}
+	valid := i.validRows(ctx, rows, &report.Rejected)
+	if report.Rejected == nil {
+		report.Rejected = []RowResult{}
+	}
+
+	all, err := i.repo.FindAll(repositories.WithDeleted(ctx))
+	if err != nil {
+		return report, err
+	}
+
+	var current []models.SwiftCode
+	stored := make(map[string]models.SwiftCode, len(all))
+	for _, code := range all {
+		stored[code.SwiftCode] = code
+		if !code.DeletedAt.Valid {
+			current = append(current, code)
+		}
+	}
+
+	incoming := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		incoming[row.Code.SwiftCode] = true
+	}
+	for _, rejection := range rejected {
+		incoming[rejection.SwiftCode] = true
+	}
+
+	var upserts []models.SwiftCode
+	for _, row := range valid {
+		existing, ok := stored[row.Code.SwiftCode]
+		if !ok {
+			report.Added = append(report.Added, changedRecord(row.Line, row.Code))
+			upserts = append(upserts, row.Code)
+			continue
+		}
+		if existing.DeletedAt.Valid {
+			restored := changedRecord(row.Line, row.Code)
+			restored.Changes = diffFields(existing, row.Code)
+			report.Restored = append(report.Restored, restored)
+			upserts = append(upserts, row.Code)
+			continue
+		}
+
+		changes := diffFields(existing, row.Code)
+		if len(changes) == 0 && sameRecord(existing, row.Code) {
+			continue
+		}
+		modified := changedRecord(row.Line, row.Code)
+		modified.Changes = changes
+		report.Modified = append(report.Modified, modified)
+		upserts = append(upserts, row.Code)
+	}
+
+	// Rows that were rejected still count as present in the release, so a
+	// typo in the file never removes the stored record.
+	var removals []string
+	for _, code := range current {
+		if !incoming[code.SwiftCode] {
+			report.Removed = append(report.Removed, changedRecord(0, code))
+			removals = append(removals, code.SwiftCode)
+		}
+	}
+
+	if dryRun || (len(upserts) == 0 && len(removals) == 0) {
+		return report, nil
+	}
+
+	return report, i.repo.ApplyChanges(ctx, upserts, removals)
+}
+
+func changedRecord(line int, code models.SwiftCode) ChangedRecord {
+	return ChangedRecord{
+		Line:        line,
+		SwiftCode:   code.SwiftCode,
+		CountryISO2: code.CountryISO2,
+		BankName:    code.Name,
+		Address:     code.Address,
+		TownName:    code.TownName,
+		TimeZone:    code.TimeZone,
+	}
+}
+
+func diffFields(old, new models.SwiftCode) []FieldChange {
+	var changes []FieldChange
+	fields := []struct {
+		name     string
+		old, new string
+	}{
+		{"bankName", old.Name, new.Name},
+		{"address", old.Address, new.Address},
+		{"townName", old.TownName, new.TownName},
+		{"timeZone", old.TimeZone, new.TimeZone},
+		{"countryName", old.CountryName, new.CountryName},
+		{"codeType", old.CodeType, new.CodeType},
+	}
+	for _, field := range fields {
+		if field.old != field.new {
+			changes = append(changes, FieldChange{Field: field.name, Old: field.old, New: field.new})
+		}
+	}
+	return changes
+}
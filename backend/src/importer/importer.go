@@ -0,0 +1,161 @@
+package importer
+
+import (
+	"RemitlyTask/src/models"
+	"RemitlyTask/src/repositories"
+	"RemitlyTask/src/validation"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	StatusInserted  = "inserted"
+	StatusRestored  = "restored"
+	StatusUpdated   = "updated"
+	StatusUnchanged = "unchanged"
+	StatusRejected  = "rejected"
+)
+
+type RowResult struct {
+	Line      int    `json:"line"`
+	SwiftCode string `json:"swiftCode,omitempty"`
+	Status    string `json:"status"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+type Summary struct {
+	Rows []RowResult
+}
+
+func (s Summary) Count(status string) int {
+	count := 0
+	for _, row := range s.Rows {
+		if row.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+type Importer struct {
+	repo         repositories.ISwiftCodeRepository
+	countryNames map[string]string
+}
+
+func NewImporter(repo repositories.ISwiftCodeRepository) *Importer {
+	return &Importer{repo: repo, countryNames: make(map[string]string)}
+}
+
+// Import upserts every valid row of a SWIFT directory file. A row of a deleted
+// code restores it. Running it twice on the same file leaves the second run
+// with only unchanged rows.
+func (i *Importer) Import(ctx context.Context, r io.Reader) (Summary, error) {
+	rows, rejected, err := ReadRows(r)
+	if err != nil {
+		return Summary{}, err
+	}
+
+	summary := Summary{Rows: rejected}
+	valid := i.validRows(ctx, rows, &summary.Rows)
+
+	for _, row := range valid {
+		status, err := i.upsert(ctx, row.Code)
+		if err != nil {
+			return summary, fmt.Errorf("line %d: %w", row.Line, err)
+		}
+		summary.Rows = append(summary.Rows, RowResult{Line: row.Line, SwiftCode: row.Code.SwiftCode, Status: status})
+	}
+
+	return summary, nil
+}
+
+// validRows filters out duplicate and invalid rows, appending a rejection for
+// each of them to results.
+func (i *Importer) validRows(ctx context.Context, rows []Row, results *[]RowResult) []Row {
+	var valid []Row
+	seen := make(map[string]int)
+
+	for _, row := range rows {
+		rejection := RowResult{Line: row.Line, SwiftCode: row.Code.SwiftCode, Status: StatusRejected}
+
+		if firstLine, ok := seen[row.Code.SwiftCode]; ok {
+			rejection.Reason = fmt.Sprintf("duplicate of line %d", firstLine)
+			*results = append(*results, rejection)
+			continue
+		}
+		seen[row.Code.SwiftCode] = row.Line
+
+		if err := i.validate(ctx, row.Code); err != nil {
+			rejection.Reason = strings.ReplaceAll(err.Error(), "\n", "; ")
+			*results = append(*results, rejection)
+			continue
+		}
+
+		valid = append(valid, row)
+	}
+
+	return valid
+}
+
+func (i *Importer) validate(ctx context.Context, code models.SwiftCode) error {
+	branch := models.SwiftCodeBranch{
+		Address:       code.Address,
+		BankName:      code.Name,
+		CountryISO2:   code.CountryISO2,
+		CountryName:   code.CountryName,
+		IsHeadquarter: code.IsHeadquarter(),
+		SwiftCode:     code.SwiftCode,
+	}
+	countryName := func(iso2 string) (string, error) { return i.countryName(ctx, iso2) }
+	if err := validation.ValidateNewSwiftCode(branch, countryName); err != nil {
+		return err
+	}
+	// The first valid row of a new country sets its name for the rows after it.
+	if iso2 := strings.ToUpper(code.CountryISO2); i.countryNames[iso2] == "" {
+		i.countryNames[iso2] = code.CountryName
+	}
+	return nil
+}
+
+func (i *Importer) countryName(ctx context.Context, iso2 string) (string, error) {
+	if name, ok := i.countryNames[iso2]; ok {
+		return name, nil
+	}
+	name, err := i.repo.FindCountryNameByISO2(ctx, iso2)
+	if err != nil {
+		return "", err
+	}
+	if name != "" {
+		i.countryNames[iso2] = name
+	}
+	return name, nil
+}
+
+func (i *Importer) upsert(ctx context.Context, code models.SwiftCode) (string, error) {
+	existing, err := i.repo.FindBySwiftCode(repositories.WithDeleted(ctx), code.SwiftCode)
+	if err != nil {
+		return "", err
+	}
+
+	status := StatusInserted
+	if existing.DeletedAt.Valid {
+		status = StatusRestored
+	} else if existing.SwiftCode != "" {
+		if sameRecord(existing, code) {
+			return StatusUnchanged, nil
+		}
+		status = StatusUpdated
+	}
+
+	if err := i.repo.Upsert(ctx, &code); err != nil {
+		return "", err
+	}
+	return status, nil
+}
+
+func sameRecord(a, b models.SwiftCode) bool {
+	a.ID, b.ID = 0, 0
+	return a == b
+}
@@ -0,0 +1,9 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS country_iso2_name_idx ON swift_codes (country_iso2, name, swift_code);
+CREATE INDEX IF NOT EXISTS swift_codes_bank_code_idx ON swift_codes (SUBSTR(swift_code, 1, 8));
+
+-- The indexed expression must stay identical to searchDocument in
+-- src/repositories/swiftCodeRepository.go.
+CREATE INDEX IF NOT EXISTS swift_codes_search_idx ON swift_codes
+    USING gin ((name || ' ' || COALESCE(address, '') || ' ' || COALESCE(town_name, '')) gin_trgm_ops);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS swift_code_audit;
+DROP FUNCTION IF EXISTS swift_code_audit_append_only();
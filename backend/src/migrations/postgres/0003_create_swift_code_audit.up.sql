@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS swift_code_audit (
+    id BIGSERIAL PRIMARY KEY,
+    swift_code VARCHAR(11) NOT NULL,
+    operation VARCHAR(10) NOT NULL,
+    actor TEXT NOT NULL,
+    claimed_actor TEXT,
+    request_id TEXT,
+    recorded_at TIMESTAMPTZ NOT NULL,
+    before_state JSONB,
+    after_state JSONB
+);
+
+CREATE INDEX IF NOT EXISTS swift_code_audit_swift_code_idx ON swift_code_audit (swift_code, id);
+
+-- The audit log is append-only: entries can't be changed or removed.
+CREATE OR REPLACE FUNCTION swift_code_audit_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'swift_code_audit is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER swift_code_audit_no_changes BEFORE UPDATE OR DELETE ON swift_code_audit
+    FOR EACH ROW EXECUTE FUNCTION swift_code_audit_append_only();
+CREATE TRIGGER swift_code_audit_no_truncate BEFORE TRUNCATE ON swift_code_audit
+    FOR EACH STATEMENT EXECUTE FUNCTION swift_code_audit_append_only();
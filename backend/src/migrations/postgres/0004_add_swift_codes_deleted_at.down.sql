@@ -0,0 +1,4 @@
+-- Without the column deleted codes would be served again, so they are dropped.
+DELETE FROM swift_codes WHERE deleted_at IS NOT NULL;
+DROP INDEX IF EXISTS swift_codes_deleted_at_idx;
+ALTER TABLE swift_codes DROP COLUMN IF EXISTS deleted_at;
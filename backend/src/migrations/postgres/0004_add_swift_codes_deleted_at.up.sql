@@ -0,0 +1,4 @@
+-- Deleted codes keep their row until the purge job removes it.
+ALTER TABLE swift_codes ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS swift_codes_deleted_at_idx ON swift_codes (deleted_at) WHERE deleted_at IS NOT NULL;
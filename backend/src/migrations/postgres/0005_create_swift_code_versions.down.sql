@@ -0,0 +1 @@
+DROP TABLE IF EXISTS swift_code_versions;
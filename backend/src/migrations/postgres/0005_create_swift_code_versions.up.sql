@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS swift_code_versions (
+    id BIGSERIAL PRIMARY KEY,
+    swift_code VARCHAR(11) NOT NULL,
+    country_iso2 CHAR(2) NOT NULL,
+    code_type VARCHAR(5) NOT NULL,
+    name TEXT NOT NULL,
+    address TEXT,
+    town_name VARCHAR(60),
+    country_name VARCHAR(50),
+    time_zone VARCHAR(50),
+    valid_from TIMESTAMPTZ NOT NULL,
+    valid_to TIMESTAMPTZ,
+    CHECK (valid_to IS NULL OR valid_to >= valid_from)
+);
+
+CREATE INDEX IF NOT EXISTS swift_code_versions_swift_code_idx ON swift_code_versions (swift_code, valid_from);
+CREATE INDEX IF NOT EXISTS swift_code_versions_country_iso2_idx ON swift_code_versions (country_iso2, valid_from);
+CREATE UNIQUE INDEX IF NOT EXISTS swift_code_versions_current_idx ON swift_code_versions (swift_code) WHERE valid_to IS NULL;
+
+-- The earlier versions of existing codes are unknown; their current version
+-- is valid since their last audited write, or since now.
+INSERT INTO swift_code_versions (swift_code, country_iso2, code_type, name, address, town_name, country_name, time_zone, valid_from)
+SELECT s.swift_code, s.country_iso2, s.code_type, s.name, s.address, s.town_name, s.country_name, s.time_zone,
+    COALESCE((SELECT MAX(a.recorded_at) FROM swift_code_audit a WHERE a.swift_code = s.swift_code), CURRENT_TIMESTAMP)
+FROM swift_codes s
+WHERE s.deleted_at IS NULL;
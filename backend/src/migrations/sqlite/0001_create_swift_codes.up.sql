@@ -1,5 +1,5 @@
 CREATE TABLE IF NOT EXISTS swift_codes (
-    id SERIAL PRIMARY KEY,
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
     country_iso2 CHAR(2) NOT NULL CHECK (LENGTH(country_iso2) = 2),
     swift_code VARCHAR(11) NOT NULL CHECK (LENGTH(swift_code) IN (8, 11)),
     code_type VARCHAR(5) NOT NULL,
@@ -7,9 +7,8 @@ CREATE TABLE IF NOT EXISTS swift_codes (
     address TEXT,
     town_name VARCHAR(60),
     country_name VARCHAR(50),
-    time_zone VARCHAR(50),
-    UNIQUE (swift_code)
+    time_zone VARCHAR(50)
 );
 
-CREATE UNIQUE INDEX swift_code_idx ON swift_codes (swift_code);
-CREATE INDEX country_iso2_idx ON swift_codes (country_iso2);
+CREATE UNIQUE INDEX IF NOT EXISTS swift_code_idx ON swift_codes (swift_code);
+CREATE INDEX IF NOT EXISTS country_iso2_idx ON swift_codes (country_iso2);
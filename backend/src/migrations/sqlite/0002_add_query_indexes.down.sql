@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS swift_codes_bank_code_idx;
+DROP INDEX IF EXISTS country_iso2_name_idx;
@@ -0,0 +1,3 @@
+-- SQLite has no trigram index; searches are ranked in Go.
+CREATE INDEX IF NOT EXISTS country_iso2_name_idx ON swift_codes (country_iso2, name, swift_code);
+CREATE INDEX IF NOT EXISTS swift_codes_bank_code_idx ON swift_codes (SUBSTR(swift_code, 1, 8));
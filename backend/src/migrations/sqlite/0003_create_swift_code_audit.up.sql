@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS swift_code_audit (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    swift_code VARCHAR(11) NOT NULL,
+    operation VARCHAR(10) NOT NULL,
+    actor TEXT NOT NULL,
+    claimed_actor TEXT,
+    request_id TEXT,
+    recorded_at TIMESTAMP NOT NULL,
+    before_state TEXT,
+    after_state TEXT
+);
+
+CREATE INDEX IF NOT EXISTS swift_code_audit_swift_code_idx ON swift_code_audit (swift_code, id);
+
+-- The audit log is append-only: entries can't be changed or removed.
+CREATE TRIGGER IF NOT EXISTS swift_code_audit_no_update BEFORE UPDATE ON swift_code_audit
+BEGIN
+    SELECT RAISE(ABORT, 'swift_code_audit is append-only');
+END;
+
+CREATE TRIGGER IF NOT EXISTS swift_code_audit_no_delete BEFORE DELETE ON swift_code_audit
+BEGIN
+    SELECT RAISE(ABORT, 'swift_code_audit is append-only');
+END;
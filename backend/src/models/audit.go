@@ -0,0 +1,79 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	AuditCreate  = "create"
+	AuditUpdate  = "update"
+	AuditDelete  = "delete"
+	AuditRestore = "restore"
+	AuditPurge   = "purge"
+)
+
+// AuditEntry is a row of swift_code_audit, the append-only log of the writes
+// to swift_codes. Each entry is written in the transaction of its write. Before
+// is nil for a creation or a restore, and After for a deletion or a purge.
+type AuditEntry struct {
+	ID           uint        `gorm:"primaryKey" json:"id"`
+	SwiftCode    string      `json:"swiftCode"`
+	Operation    string      `json:"operation"`
+	Actor        string      `json:"actor"`
+	ClaimedActor string      `json:"claimedActor,omitempty"`
+	RequestID    string      `json:"requestId,omitempty"`
+	Timestamp    time.Time   `gorm:"column:recorded_at" json:"timestamp"`
+	Before       *AuditState `gorm:"column:before_state" json:"before"`
+	After        *AuditState `gorm:"column:after_state" json:"after"`
+}
+
+func (AuditEntry) TableName() string {
+	return "swift_code_audit"
+}
+
+// AuditState is a code as recorded by the audit log. It is stored as JSON.
+type AuditState struct {
+	Address     string `json:"address"`
+	BankName    string `json:"bankName"`
+	CountryISO2 string `json:"countryISO2"`
+	CountryName string `json:"countryName"`
+	TownName    string `json:"townName"`
+	TimeZone    string `json:"timeZone"`
+	CodeType    string `json:"codeType"`
+}
+
+func NewAuditState(code SwiftCode) *AuditState {
+	return &AuditState{
+		Address:     code.Address,
+		BankName:    code.Name,
+		CountryISO2: code.CountryISO2,
+		CountryName: code.CountryName,
+		TownName:    code.TownName,
+		TimeZone:    code.TimeZone,
+		CodeType:    code.CodeType,
+	}
+}
+
+func (s AuditState) Value() (driver.Value, error) {
+	data, err := json.Marshal(s)
+	return string(data), err
+}
+
+func (s *AuditState) Scan(value interface{}) error {
+	switch value := value.(type) {
+	case []byte:
+		return json.Unmarshal(value, s)
+	case string:
+		return json.Unmarshal([]byte(value), s)
+	default:
+		return fmt.Errorf("cannot scan %T into an audit state", value)
+	}
+}
+
+type SwiftCodeHistory struct {
+	SwiftCode string       `json:"swiftCode"`
+	Entries   []AuditEntry `json:"entries"`
+}
@@ -0,0 +1,53 @@
+package models
+
+import "errors"
+
+// Domain errors returned by repositories and services. Callers match them with
+// errors.Is; the errors that wrap them carry the details.
+var (
+	ErrNotFound      = errors.New("not found")
+	ErrAlreadyExists = errors.New("already exists")
+	ErrValidation    = errors.New("validation failed")
+	ErrConflict      = errors.New("conflicts with the stored data")
+)
+
+// Machine-readable codes of Problem.
+const (
+	ErrorCodeValidation    = "validation_failed"
+	ErrorCodeNotFound      = "not_found"
+	ErrorCodeAlreadyExists = "already_exists"
+	ErrorCodeConflict      = "conflict"
+	ErrorCodeReadOnly      = "read_only"
+	ErrorCodeTimeout       = "timeout"
+	ErrorCodeInternal      = "internal_error"
+)
+
+const ProblemContentType = "application/problem+json"
+
+// ProblemType is the type URI of the problems with a machine-readable code.
+func ProblemType(code string) string {
+	return "urn:swift-codes:problem:" + code
+}
+
+// Problem is the RFC 7807 problem details document every failed request is
+// answered with. Code is an extension member holding the machine-readable
+// code of Type. Message and Field are only set for clients of the earlier
+// error format, when the compatibility switch is on.
+type Problem struct {
+	Type     string         `json:"type"`
+	Title    string         `json:"title"`
+	Status   int            `json:"status"`
+	Detail   string         `json:"detail,omitempty"`
+	Instance string         `json:"instance,omitempty"`
+	Code     string         `json:"code"`
+	Errors   []FieldProblem `json:"errors,omitempty"`
+	Message  string         `json:"message,omitempty"`
+	Field    string         `json:"field,omitempty"`
+}
+
+// FieldProblem is a field of the request body that failed validation.
+type FieldProblem struct {
+	Field  string `json:"field"`
+	Value  string `json:"value"`
+	Detail string `json:"detail"`
+}
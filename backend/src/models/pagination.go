@@ -0,0 +1,56 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
+const (
+	SortBySwiftCode = "swiftCode"
+	SortByBankName  = "bankName"
+	SortByTownName  = "townName"
+)
+
+type CountryQuery struct {
+	Limit         int
+	SortBy        string
+	Descending    bool
+	IsHeadquarter *bool
+	Town          string
+	After         *CountryCursor
+}
+
+// CountryCursor points at the last code of a page. It records the ordering it
+// was issued for so it can't be replayed against a different one.
+type CountryCursor struct {
+	SortBy     string `json:"s"`
+	Descending bool   `json:"d,omitempty"`
+	Value      string `json:"v,omitempty"`
+	SwiftCode  string `json:"c"`
+}
+
+func EncodeCursor(cursor CountryCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(encoded string) (CountryCursor, error) {
+	var cursor CountryCursor
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return cursor, err
+	}
+	err = json.Unmarshal(data, &cursor)
+	return cursor, err
+}
+
+func (s *SwiftCode) SortValue(sortBy string) string {
+	switch sortBy {
+	case SortByBankName:
+		return s.Name
+	case SortByTownName:
+		return s.TownName
+	default:
+		return s.SwiftCode
+	}
+}
@@ -1,23 +1,41 @@
 package models
 
-import "strings"
+import (
+	"strings"
+	"time"
 
+	"gorm.io/gorm"
+)
+
+// SwiftCode is a row of swift_codes. The schema is defined by the migrations
+// in src/migrations. Deleted codes keep their row with DeletedAt set until
+// they are purged, and are left out of queries unless asked for.
 type SwiftCode struct {
-	ID          uint   `gorm:"primaryKey;autoIncrement" json:"-"`
-	Address     string `gorm:"type:text" json:"address"`
-	Name        string `gorm:"type:text;not null" json:"bankName"`
-	CountryISO2 string `gorm:"type:char(2);not null;check:length(country_iso2) = 2" json:"countryISO2"`
-	SwiftCode   string `gorm:"type:varchar(11);not null;unique;check:length(swift_code) in (8, 11)" json:"swiftCode"`
-	CodeType    string `gorm:"type:varchar(5);not null" json:"-"`
-	TownName    string `gorm:"type:varchar(60)" json:"-"`
-	CountryName string `gorm:"type:varchar(50)" json:"countryName,omitempty"`
-	TimeZone    string `gorm:"type:varchar(50)" json:"-"`
+	ID          uint           `gorm:"primaryKey" json:"-"`
+	Address     string         `json:"address"`
+	Name        string         `json:"bankName"`
+	CountryISO2 string         `json:"countryISO2"`
+	SwiftCode   string         `json:"swiftCode"`
+	CodeType    string         `json:"-"`
+	TownName    string         `json:"-"`
+	CountryName string         `json:"countryName,omitempty"`
+	TimeZone    string         `json:"-"`
+	DeletedAt   gorm.DeletedAt `json:"-"`
 }
 
 func (s *SwiftCode) IsHeadquarter() bool {
 	return strings.HasSuffix(s.SwiftCode, "XXX")
 }
 
+// DeletionTime is when the code was deleted, nil if it wasn't.
+func (s *SwiftCode) DeletionTime() *time.Time {
+	if !s.DeletedAt.Valid {
+		return nil
+	}
+	deletedAt := s.DeletedAt.Time
+	return &deletedAt
+}
+
 type SwiftCodeDetails struct {
 	Address       string          `json:"address"`
 	BankName      string          `json:"bankName"`
@@ -26,27 +44,108 @@ type SwiftCodeDetails struct {
 	IsHeadquarter bool            `json:"isHeadquarter"`
 	SwiftCode     string          `json:"swiftCode"`
 	Branches      []SwiftCodeBank `json:"branches"`
+	DeletedAt     *time.Time      `json:"deletedAt,omitempty"`
 }
 
 type SwiftCodeBranch struct {
-	Address       string `json:"address"`
-	BankName      string `json:"bankName"`
-	CountryISO2   string `json:"countryISO2"`
-	CountryName   string `json:"countryName"`
-	IsHeadquarter bool   `json:"isHeadquarter"`
-	SwiftCode     string `json:"swiftCode"`
+	Address       string     `json:"address"`
+	BankName      string     `json:"bankName"`
+	CountryISO2   string     `json:"countryISO2"`
+	CountryName   string     `json:"countryName"`
+	IsHeadquarter bool       `json:"isHeadquarter"`
+	SwiftCode     string     `json:"swiftCode"`
+	DeletedAt     *time.Time `json:"deletedAt,omitempty"`
 }
 
 type SwiftCodeCountry struct {
 	CountryISO2 string          `json:"countryISO2"`
 	CountryName string          `json:"countryName"`
 	SwiftCodes  []SwiftCodeBank `json:"swiftCodes"`
+	NextCursor  string          `json:"nextCursor,omitempty"`
 }
 
 type SwiftCodeBank struct {
-	Address       string `json:"address"`
-	BankName      string `json:"bankName"`
-	CountryISO2   string `json:"countryISO2"`
-	IsHeadquarter bool   `json:"isHeadquarter"`
-	SwiftCode     string `json:"swiftCode"`
+	Address       string     `json:"address"`
+	BankName      string     `json:"bankName"`
+	CountryISO2   string     `json:"countryISO2"`
+	IsHeadquarter bool       `json:"isHeadquarter"`
+	SwiftCode     string     `json:"swiftCode"`
+	DeletedAt     *time.Time `json:"deletedAt,omitempty"`
+}
+
+type SwiftCodePatch struct {
+	Address     *string `json:"address"`
+	BankName    *string `json:"bankName"`
+	TownName    *string `json:"townName"`
+	TimeZone    *string `json:"timeZone"`
+	CountryISO2 *string `json:"countryISO2"`
+	SwiftCode   *string `json:"swiftCode"`
+}
+
+type SwiftCodeSearchResult struct {
+	SwiftCodeBank
+	Score float64 `json:"score"`
+}
+
+type SwiftCodeSearch struct {
+	Query   string                  `json:"query"`
+	Results []SwiftCodeSearchResult `json:"results"`
+}
+
+type SwiftCodeSuggestion struct {
+	SwiftCode   string `json:"swiftCode"`
+	BankName    string `json:"bankName"`
+	CountryISO2 string `json:"countryISO2"`
+}
+
+type SwiftCodeSuggestions struct {
+	Prefix      string                `json:"prefix"`
+	Suggestions []SwiftCodeSuggestion `json:"suggestions"`
+}
+
+const (
+	LookupErrorInvalidFormat = "invalid_format"
+	LookupErrorNotFound      = "not_found"
+)
+
+type LookupError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type SwiftCodeLookupResult struct {
+	SwiftCode string       `json:"swiftCode"`
+	Found     bool         `json:"found"`
+	Details   interface{}  `json:"details,omitempty"`
+	Error     *LookupError `json:"error,omitempty"`
+}
+
+type SwiftCodeLookup struct {
+	Results []SwiftCodeLookupResult `json:"results"`
+}
+
+const (
+	BulkStatusCreated  = "created"
+	BulkStatusRejected = "rejected"
+	BulkStatusSkipped  = "skipped"
+)
+
+type BulkItemResult struct {
+	Index     int    `json:"index"`
+	SwiftCode string `json:"swiftCode"`
+	Status    string `json:"status"`
+	Message   string `json:"message"`
+	Field     string `json:"field,omitempty"`
+}
+
+type BulkResult struct {
+	Atomic   bool             `json:"atomic"`
+	Created  int              `json:"created"`
+	Rejected int              `json:"rejected"`
+	Results  []BulkItemResult `json:"results"`
+}
+
+type ScoredSwiftCode struct {
+	Code  SwiftCode `gorm:"embedded"`
+	Score float64
 }
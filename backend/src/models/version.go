@@ -0,0 +1,54 @@
+package models
+
+import "time"
+
+// SwiftCodeVersion is a row of swift_code_versions: the fields a code had from
+// ValidFrom until ValidTo, which is nil for the current version. Each write
+// closes the current version of the code and, unless it deleted the code,
+// opens the next one.
+type SwiftCodeVersion struct {
+	ID          uint `gorm:"primaryKey"`
+	SwiftCode   string
+	CountryISO2 string
+	CodeType    string
+	Name        string
+	Address     string
+	TownName    string
+	CountryName string
+	TimeZone    string
+	ValidFrom   time.Time
+	ValidTo     *time.Time
+}
+
+func NewSwiftCodeVersion(swiftCode string, state AuditState, validFrom time.Time) SwiftCodeVersion {
+	return SwiftCodeVersion{
+		SwiftCode:   swiftCode,
+		CountryISO2: state.CountryISO2,
+		CodeType:    state.CodeType,
+		Name:        state.BankName,
+		Address:     state.Address,
+		TownName:    state.TownName,
+		CountryName: state.CountryName,
+		TimeZone:    state.TimeZone,
+		ValidFrom:   validFrom,
+	}
+}
+
+// ValidAt reports whether the version was the current one at t.
+func (v SwiftCodeVersion) ValidAt(t time.Time) bool {
+	return !v.ValidFrom.After(t) && (v.ValidTo == nil || v.ValidTo.After(t))
+}
+
+// Code is the code as it was while the version was current.
+func (v SwiftCodeVersion) Code() SwiftCode {
+	return SwiftCode{
+		SwiftCode:   v.SwiftCode,
+		CountryISO2: v.CountryISO2,
+		CodeType:    v.CodeType,
+		Name:        v.Name,
+		Address:     v.Address,
+		TownName:    v.TownName,
+		CountryName: v.CountryName,
+		TimeZone:    v.TimeZone,
+	}
+}
@@ -0,0 +1,15 @@
+// Package openapi holds the OpenAPI document of the API and the Swagger UI
+// configuration that displays it. The document is maintained by hand next to
+// the routes in src/router.
+package openapi
+
+import _ "embed"
+
+//go:embed openapi.json
+var Spec []byte
+
+// SwaggerInitializer replaces the initializer of the Swagger UI distribution,
+// which would load the petstore example.
+//
+//go:embed swagger-initializer.js
+var SwaggerInitializer []byte
@@ -0,0 +1,55 @@
+// Package proxy decides which peers are trusted to name the caller of a
+// request. Only the proxies in front of the server, which authenticate
+// callers, should be; the headers of any other peer can be forged.
+package proxy
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+)
+
+// Trusted is a list of trusted proxies. The zero value trusts no peer.
+type Trusted []netip.Prefix
+
+// Parse reads proxies given as IP addresses or CIDR ranges, the format of
+// gin's SetTrustedProxies.
+func Parse(proxies []string) (Trusted, error) {
+	trusted := make(Trusted, 0, len(proxies))
+	for _, p := range proxies {
+		if strings.Contains(p, "/") {
+			prefix, err := netip.ParsePrefix(p)
+			if err != nil {
+				return nil, fmt.Errorf("trusted proxy %q must be an IP address or a CIDR range", p)
+			}
+			trusted = append(trusted, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(p)
+		if err != nil {
+			return nil, fmt.Errorf("trusted proxy %q must be an IP address or a CIDR range", p)
+		}
+		trusted = append(trusted, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return trusted, nil
+}
+
+// Contains reports whether the peer at addr, an IP address with or without a
+// port, is a trusted proxy.
+func (t Trusted) Contains(addr string) bool {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+	ip, err := netip.ParseAddr(addr)
+	if err != nil {
+		return false
+	}
+	ip = ip.Unmap()
+	for _, prefix := range t {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
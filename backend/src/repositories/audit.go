@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"RemitlyTask/src/models"
+	"context"
+	"fmt"
+	"time"
+)
+
+// SystemActor is recorded for writes made without an actor in their context,
+// such as seeding the directory at startup.
+const SystemActor = "system"
+
+// Actor is who made a write, recorded with it in swift_code_audit. Claimed is
+// the name a caller gave for itself that couldn't be trusted, recorded apart
+// from Name.
+type Actor struct {
+	Name      string
+	Claimed   string
+	RequestID string
+}
+
+type actorKey struct{}
+
+// WithActor attributes the writes made with ctx to actor.
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+func actorFrom(ctx context.Context) Actor {
+	actor, _ := ctx.Value(actorKey{}).(Actor)
+	if actor.Name == "" {
+		actor.Name = SystemActor
+	}
+	return actor
+}
+
+// auditEntry records a write to swiftCode by the actor of ctx. before is nil
+// when the code was created and after when it was deleted.
+func auditEntry(ctx context.Context, operation, swiftCode string, before, after *models.SwiftCode) models.AuditEntry {
+	actor := actorFrom(ctx)
+	entry := models.AuditEntry{
+		SwiftCode:    swiftCode,
+		Operation:    operation,
+		Actor:        actor.Name,
+		ClaimedActor: actor.Claimed,
+		RequestID:    actor.RequestID,
+		Timestamp:    time.Now().UTC(),
+	}
+	if before != nil {
+		entry.Before = models.NewAuditState(*before)
+	}
+	if after != nil {
+		entry.After = models.NewAuditState(*after)
+	}
+	return entry
+}
+
+// existsError is the error of creating a code over stored.
+func existsError(stored models.SwiftCode) error {
+	if stored.DeletedAt.Valid {
+		return fmt.Errorf("SWIFT code %s %w as a deleted code, restore it instead", stored.SwiftCode, models.ErrAlreadyExists)
+	}
+	return fmt.Errorf("SWIFT code %s %w", stored.SwiftCode, models.ErrAlreadyExists)
+}
+
+// checkBranches checks that the headquarter swiftCode can be deleted along with
+// codes, the codes of its bank.
+func checkBranches(swiftCode string, codes []models.SwiftCode, cascade bool) error {
+	found, branches := false, 0
+	for _, code := range codes {
+		if code.SwiftCode == swiftCode {
+			found = true
+		} else {
+			branches++
+		}
+	}
+	if !found {
+		return fmt.Errorf("SWIFT code %s %w", swiftCode, models.ErrNotFound)
+	}
+	if branches > 0 && !cascade {
+		return fmt.Errorf("SWIFT code %s has %d branches: %w", swiftCode, branches, models.ErrConflict)
+	}
+	return nil
+}
+
+// upsertEntry records the upsert of code over stored, if there was a stored
+// code. Upserting a deleted code restores it, with the data of code. ok is
+// false when the upsert changed nothing.
+func upsertEntry(ctx context.Context, code models.SwiftCode, stored models.SwiftCode, exists bool) (entry models.AuditEntry, ok bool) {
+	if !exists {
+		return auditEntry(ctx, models.AuditCreate, code.SwiftCode, nil, &code), true
+	}
+	if stored.DeletedAt.Valid {
+		return auditEntry(ctx, models.AuditRestore, code.SwiftCode, nil, &code), true
+	}
+	if *models.NewAuditState(stored) == *models.NewAuditState(code) {
+		return entry, false
+	}
+	return auditEntry(ctx, models.AuditUpdate, code.SwiftCode, &stored, &code), true
+}
@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"RemitlyTask/src/models"
+	"context"
+)
+
+type deletedKey struct{}
+
+// WithDeleted makes the queries run with ctx include deleted codes that
+// haven't been purged yet. Writes still only see the codes that aren't
+// deleted.
+func WithDeleted(ctx context.Context) context.Context {
+	return context.WithValue(ctx, deletedKey{}, true)
+}
+
+func includesDeleted(ctx context.Context) bool {
+	include, _ := ctx.Value(deletedKey{}).(bool)
+	return include
+}
+
+// visible reports whether a query run with ctx returns code.
+func visible(ctx context.Context, code models.SwiftCode) bool {
+	return !code.DeletedAt.Valid || includesDeleted(ctx)
+}
@@ -0,0 +1,488 @@
+package repositories
+
+import (
+	"RemitlyTask/src/models"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MemorySwiftCodeRepository keeps the directory in memory. Codes are indexed by
+// SWIFT code, in a sorted slice for prefix scans, and by country. Deleted codes
+// stay in the indexes until they are purged. Versions are indexed by SWIFT
+// code, oldest first, and every code that ever had one in a sorted slice, which
+// reads as of a past time go through instead. Only the scans over many codes,
+// Search and Iterate, stop when ctx is done.
+type MemorySwiftCodeRepository struct {
+	mu         sync.RWMutex
+	codes      map[string]models.SwiftCode
+	sorted     []string
+	byCountry  map[string][]string
+	nextID     uint
+	audit      []models.AuditEntry
+	versions   []models.SwiftCodeVersion
+	versionsOf map[string][]int
+	versioned  []string
+}
+
+func NewMemorySwiftCodeRepository() *MemorySwiftCodeRepository {
+	return &MemorySwiftCodeRepository{
+		codes:      make(map[string]models.SwiftCode),
+		byCountry:  make(map[string][]string),
+		nextID:     1,
+		versionsOf: make(map[string][]int),
+	}
+}
+
+func (r *MemorySwiftCodeRepository) FindBySwiftCodePrefix(ctx context.Context, prefix string) ([]models.SwiftCode, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.prefixRange(ctx, prefix), nil
+}
+
+func (r *MemorySwiftCodeRepository) FindBySwiftCode(ctx context.Context, code string) (models.SwiftCode, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	stored, _ := r.code(ctx, code)
+	return stored, nil
+}
+
+func (r *MemorySwiftCodeRepository) FindByBankCodes(ctx context.Context, bankCodes []string) ([]models.SwiftCode, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	unique := make([]string, 0, len(bankCodes))
+	seen := make(map[string]bool, len(bankCodes))
+	for _, bankCode := range bankCodes {
+		if len(bankCode) == 8 && !seen[bankCode] {
+			seen[bankCode] = true
+			unique = append(unique, bankCode)
+		}
+	}
+	sort.Strings(unique)
+
+	swiftCodes := []models.SwiftCode{}
+	for _, bankCode := range unique {
+		swiftCodes = append(swiftCodes, r.prefixRange(ctx, bankCode)...)
+	}
+	return swiftCodes, nil
+}
+
+func (r *MemorySwiftCodeRepository) FindCountryNameByISO2(ctx context.Context, iso2 string) (string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, swiftCode := range r.keys(ctx, iso2) {
+		if code, ok := r.code(ctx, swiftCode); ok && code.CountryISO2 == iso2 {
+			return code.CountryName, nil
+		}
+	}
+	return "", nil
+}
+
+func (r *MemorySwiftCodeRepository) FindByCountryISO2(ctx context.Context, iso2 string, query models.CountryQuery) ([]models.SwiftCode, error) {
+	r.mu.RLock()
+	swiftCodes := []models.SwiftCode{}
+	for _, swiftCode := range r.keys(ctx, iso2) {
+		code, ok := r.code(ctx, swiftCode)
+		if !ok || code.CountryISO2 != iso2 {
+			continue
+		}
+		if query.IsHeadquarter != nil && code.IsHeadquarter() != *query.IsHeadquarter {
+			continue
+		}
+		if query.Town != "" && !strings.EqualFold(code.TownName, query.Town) {
+			continue
+		}
+		swiftCodes = append(swiftCodes, code)
+	}
+	r.mu.RUnlock()
+
+	sortBy := query.SortBy
+	if _, ok := sortColumns[sortBy]; !ok {
+		sortBy = models.SortBySwiftCode
+	}
+	compare := func(a, b *models.SwiftCode) int {
+		if c := strings.Compare(a.SortValue(sortBy), b.SortValue(sortBy)); c != 0 || sortBy == models.SortBySwiftCode {
+			return c
+		}
+		return strings.Compare(a.SwiftCode, b.SwiftCode)
+	}
+	if query.Descending {
+		ascending := compare
+		compare = func(a, b *models.SwiftCode) int { return ascending(b, a) }
+	}
+	sort.Slice(swiftCodes, func(i, j int) bool { return compare(&swiftCodes[i], &swiftCodes[j]) < 0 })
+
+	if query.After != nil {
+		after := models.SwiftCode{SwiftCode: query.After.SwiftCode, Name: query.After.Value, TownName: query.After.Value}
+		start := sort.Search(len(swiftCodes), func(i int) bool { return compare(&swiftCodes[i], &after) > 0 })
+		swiftCodes = swiftCodes[start:]
+	}
+	if query.Limit > 0 && len(swiftCodes) > query.Limit {
+		swiftCodes = swiftCodes[:query.Limit]
+	}
+	return swiftCodes, nil
+}
+
+func (r *MemorySwiftCodeRepository) Search(ctx context.Context, query string, iso2 string, limit int) ([]models.ScoredSwiftCode, error) {
+	results := []models.ScoredSwiftCode{}
+	search := newTrigramSearch(query)
+	if search.empty() {
+		return results, nil
+	}
+
+	r.mu.RLock()
+	for _, swiftCode := range r.keys(ctx, iso2) {
+		if err := ctx.Err(); err != nil {
+			r.mu.RUnlock()
+			return nil, err
+		}
+		code, ok := r.code(ctx, swiftCode)
+		if !ok || (iso2 != "" && code.CountryISO2 != iso2) {
+			continue
+		}
+		if score, ok := search.score(code); ok {
+			results = append(results, models.ScoredSwiftCode{Code: code, Score: score})
+		}
+	}
+	r.mu.RUnlock()
+
+	return rankSearchResults(results, limit), nil
+}
+
+func (r *MemorySwiftCodeRepository) Create(ctx context.Context, newCode *models.SwiftCode) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stored, ok := r.codes[newCode.SwiftCode]; ok {
+		return existsError(stored)
+	}
+	r.put(newCode)
+	r.record(auditEntry(ctx, models.AuditCreate, newCode.SwiftCode, nil, newCode))
+	return nil
+}
+
+func (r *MemorySwiftCodeRepository) CreateBatch(ctx context.Context, newCodes []*models.SwiftCode) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	seen := make(map[string]bool, len(newCodes))
+	for _, newCode := range newCodes {
+		if _, ok := r.codes[newCode.SwiftCode]; ok || seen[newCode.SwiftCode] {
+			return fmt.Errorf("SWIFT code %s %w", newCode.SwiftCode, models.ErrAlreadyExists)
+		}
+		seen[newCode.SwiftCode] = true
+	}
+	for _, newCode := range newCodes {
+		r.put(newCode)
+		r.record(auditEntry(ctx, models.AuditCreate, newCode.SwiftCode, nil, newCode))
+	}
+	return nil
+}
+
+func (r *MemorySwiftCodeRepository) Upsert(ctx context.Context, code *models.SwiftCode) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.upsert(ctx, code)
+	return nil
+}
+
+func (r *MemorySwiftCodeRepository) Update(ctx context.Context, code *models.SwiftCode) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.codes[code.SwiftCode]
+	if !ok || stored.DeletedAt.Valid {
+		return fmt.Errorf("SWIFT code %s %w", code.SwiftCode, models.ErrNotFound)
+	}
+	before := stored
+	stored.Address = code.Address
+	stored.Name = code.Name
+	stored.TownName = code.TownName
+	stored.TimeZone = code.TimeZone
+	r.codes[code.SwiftCode] = stored
+	r.record(auditEntry(ctx, models.AuditUpdate, code.SwiftCode, &before, &stored))
+	return nil
+}
+
+func (r *MemorySwiftCodeRepository) FindAll(ctx context.Context) ([]models.SwiftCode, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	swiftCodes := []models.SwiftCode{}
+	for _, swiftCode := range r.keys(ctx, "") {
+		if code, ok := r.code(ctx, swiftCode); ok {
+			swiftCodes = append(swiftCodes, code)
+		}
+	}
+	return swiftCodes, nil
+}
+
+// Iterate copies the codes first so fn may call back into the repository.
+func (r *MemorySwiftCodeRepository) Iterate(ctx context.Context, iso2 string, fn func(models.SwiftCode) error) error {
+	r.mu.RLock()
+	var swiftCodes []models.SwiftCode
+	for _, swiftCode := range r.keys(ctx, iso2) {
+		if code, ok := r.code(ctx, swiftCode); ok && (iso2 == "" || code.CountryISO2 == iso2) {
+			swiftCodes = append(swiftCodes, code)
+		}
+	}
+	r.mu.RUnlock()
+
+	for _, code := range swiftCodes {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(code); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *MemorySwiftCodeRepository) ApplyChanges(ctx context.Context, upserts []models.SwiftCode, removals []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, swiftCode := range removals {
+		r.delete(ctx, swiftCode)
+	}
+	for i := range upserts {
+		r.upsert(ctx, &upserts[i])
+	}
+	return nil
+}
+
+func (r *MemorySwiftCodeRepository) Delete(ctx context.Context, swiftCode string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.delete(ctx, swiftCode) {
+		return fmt.Errorf("SWIFT code %s %w", swiftCode, models.ErrNotFound)
+	}
+	return nil
+}
+
+// DeleteHeadquarter deletes a headquarter, and its branches when cascade is
+// set, under one lock. See SwiftCodeRepository.DeleteHeadquarter.
+func (r *MemorySwiftCodeRepository) DeleteHeadquarter(ctx context.Context, swiftCode string, cascade bool) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var codes []models.SwiftCode
+	for i := sort.SearchStrings(r.sorted, swiftCode[:8]); i < len(r.sorted) && strings.HasPrefix(r.sorted[i], swiftCode[:8]); i++ {
+		if code := r.codes[r.sorted[i]]; !code.DeletedAt.Valid {
+			codes = append(codes, code)
+		}
+	}
+	if err := checkBranches(swiftCode, codes, cascade); err != nil {
+		return nil, err
+	}
+
+	removed := make([]string, len(codes))
+	for i, code := range codes {
+		removed[i] = code.SwiftCode
+		r.delete(ctx, code.SwiftCode)
+	}
+	return removed, nil
+}
+
+// Restore undoes the deletion of a code that hasn't been purged yet.
+func (r *MemorySwiftCodeRepository) Restore(ctx context.Context, swiftCode string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.codes[swiftCode]
+	if !ok {
+		return fmt.Errorf("SWIFT code %s %w", swiftCode, models.ErrNotFound)
+	}
+	if !stored.DeletedAt.Valid {
+		return fmt.Errorf("SWIFT code %s is not deleted: %w", swiftCode, models.ErrConflict)
+	}
+	stored.DeletedAt = gorm.DeletedAt{}
+	r.codes[swiftCode] = stored
+	r.record(auditEntry(ctx, models.AuditRestore, swiftCode, nil, &stored))
+	return nil
+}
+
+// Purge removes the codes deleted before deletedBefore for good and returns
+// how many there were.
+func (r *MemorySwiftCodeRepository) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var purged []models.SwiftCode
+	for _, swiftCode := range r.sorted {
+		if code := r.codes[swiftCode]; code.DeletedAt.Valid && code.DeletedAt.Time.Before(deletedBefore) {
+			purged = append(purged, code)
+		}
+	}
+	for _, code := range purged {
+		r.remove(code.SwiftCode)
+		r.record(auditEntry(ctx, models.AuditPurge, code.SwiftCode, &code, nil))
+	}
+	return len(purged), nil
+}
+
+// FindHistory returns the audit entries of a code, oldest first.
+func (r *MemorySwiftCodeRepository) FindHistory(ctx context.Context, swiftCode string) ([]models.AuditEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var entries []models.AuditEntry
+	for _, entry := range r.audit {
+		if entry.SwiftCode == swiftCode {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// upsert puts code and records the write, unless it changed nothing. A
+// deleted code is restored. The caller must hold the write lock.
+func (r *MemorySwiftCodeRepository) upsert(ctx context.Context, code *models.SwiftCode) {
+	stored, exists := r.codes[code.SwiftCode]
+	r.put(code)
+	if entry, ok := upsertEntry(ctx, *code, stored, exists); ok {
+		r.record(entry)
+	}
+}
+
+// delete marks a code as deleted and records the write. The caller must hold
+// the write lock.
+func (r *MemorySwiftCodeRepository) delete(ctx context.Context, swiftCode string) bool {
+	stored, ok := r.codes[swiftCode]
+	if !ok || stored.DeletedAt.Valid {
+		return false
+	}
+	before := stored
+	stored.DeletedAt = gorm.DeletedAt{Time: time.Now().UTC(), Valid: true}
+	r.codes[swiftCode] = stored
+	r.record(auditEntry(ctx, models.AuditDelete, swiftCode, &before, nil))
+	return true
+}
+
+// record appends an entry to the audit log, numbering it like the ID column of
+// swift_code_audit, and closes and opens the versions of the code like
+// recordEntries. The caller must hold the write lock.
+func (r *MemorySwiftCodeRepository) record(entry models.AuditEntry) {
+	entry.ID = uint(len(r.audit) + 1)
+	r.audit = append(r.audit, entry)
+
+	indexes := r.versionsOf[entry.SwiftCode]
+	if len(indexes) > 0 && r.versions[indexes[len(indexes)-1]].ValidTo == nil {
+		validTo := entry.Timestamp
+		r.versions[indexes[len(indexes)-1]].ValidTo = &validTo
+	}
+	if entry.After != nil {
+		if len(indexes) == 0 {
+			r.versioned = insertSorted(r.versioned, entry.SwiftCode)
+		}
+		r.versionsOf[entry.SwiftCode] = append(indexes, len(r.versions))
+		r.versions = append(r.versions, models.NewSwiftCodeVersion(entry.SwiftCode, *entry.After, entry.Timestamp))
+	}
+}
+
+// code is swiftCode as ctx reads it: its version valid at the time ctx asks
+// for, if it asks for one, or else the stored code if ctx may see it. The
+// caller must hold the read lock.
+func (r *MemorySwiftCodeRepository) code(ctx context.Context, swiftCode string) (models.SwiftCode, bool) {
+	asOf, ok := asOfFrom(ctx)
+	if !ok {
+		if stored, ok := r.codes[swiftCode]; ok && visible(ctx, stored) {
+			return stored, true
+		}
+		return models.SwiftCode{}, false
+	}
+
+	indexes := r.versionsOf[swiftCode]
+	for i := len(indexes) - 1; i >= 0; i-- {
+		version := r.versions[indexes[i]]
+		if version.ValidAt(asOf) {
+			return version.Code(), true
+		}
+		if !version.ValidFrom.After(asOf) {
+			break
+		}
+	}
+	return models.SwiftCode{}, false
+}
+
+// keys are the sorted SWIFT codes a read with ctx goes through, a superset of
+// those of the country iso2 unless it is empty. Reads as of a past time go
+// through every code that had a version, whatever its country was then. The
+// caller must hold the read lock.
+func (r *MemorySwiftCodeRepository) keys(ctx context.Context, iso2 string) []string {
+	if _, ok := asOfFrom(ctx); ok {
+		return r.versioned
+	}
+	if iso2 != "" {
+		return r.byCountry[iso2]
+	}
+	return r.sorted
+}
+
+// put inserts or replaces code, assigning an ID to new codes. The caller must
+// hold the write lock.
+func (r *MemorySwiftCodeRepository) put(code *models.SwiftCode) {
+	if stored, ok := r.codes[code.SwiftCode]; ok {
+		code.ID = stored.ID
+		if stored.CountryISO2 != code.CountryISO2 {
+			r.byCountry[stored.CountryISO2] = removeSorted(r.byCountry[stored.CountryISO2], code.SwiftCode)
+			r.byCountry[code.CountryISO2] = insertSorted(r.byCountry[code.CountryISO2], code.SwiftCode)
+		}
+	} else {
+		code.ID = r.nextID
+		r.nextID++
+		r.sorted = insertSorted(r.sorted, code.SwiftCode)
+		r.byCountry[code.CountryISO2] = insertSorted(r.byCountry[code.CountryISO2], code.SwiftCode)
+	}
+	r.codes[code.SwiftCode] = *code
+}
+
+func (r *MemorySwiftCodeRepository) remove(swiftCode string) bool {
+	stored, ok := r.codes[swiftCode]
+	if !ok {
+		return false
+	}
+	delete(r.codes, swiftCode)
+	r.sorted = removeSorted(r.sorted, swiftCode)
+	r.byCountry[stored.CountryISO2] = removeSorted(r.byCountry[stored.CountryISO2], swiftCode)
+	if len(r.byCountry[stored.CountryISO2]) == 0 {
+		delete(r.byCountry, stored.CountryISO2)
+	}
+	return true
+}
+
+func (r *MemorySwiftCodeRepository) prefixRange(ctx context.Context, prefix string) []models.SwiftCode {
+	swiftCodes := []models.SwiftCode{}
+	keys := r.keys(ctx, "")
+	for i := sort.SearchStrings(keys, prefix); i < len(keys) && strings.HasPrefix(keys[i], prefix); i++ {
+		if code, ok := r.code(ctx, keys[i]); ok {
+			swiftCodes = append(swiftCodes, code)
+		}
+	}
+	return swiftCodes
+}
+
+func insertSorted(keys []string, key string) []string {
+	i := sort.SearchStrings(keys, key)
+	if i < len(keys) && keys[i] == key {
+		return keys
+	}
+	keys = append(keys, "")
+	copy(keys[i+1:], keys[i:])
+	keys[i] = key
+	return keys
+}
+
+func removeSorted(keys []string, key string) []string {
+	i := sort.SearchStrings(keys, key)
+	if i == len(keys) || keys[i] != key {
+		return keys
+	}
+	return append(keys[:i], keys[i+1:]...)
+}
@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"RemitlyTask/src/models"
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrReadOnly = errors.New("the SWIFT code directory is read-only")
+
+// ReadOnlySwiftCodeRepository serves the reads of another repository and
+// refuses every write.
+type ReadOnlySwiftCodeRepository struct {
+	ISwiftCodeRepository
+}
+
+func NewReadOnlySwiftCodeRepository(repo ISwiftCodeRepository) ISwiftCodeRepository {
+	return &ReadOnlySwiftCodeRepository{ISwiftCodeRepository: repo}
+}
+
+func (r *ReadOnlySwiftCodeRepository) Create(ctx context.Context, newCode *models.SwiftCode) error {
+	return ErrReadOnly
+}
+
+func (r *ReadOnlySwiftCodeRepository) CreateBatch(ctx context.Context, newCodes []*models.SwiftCode) error {
+	return ErrReadOnly
+}
+
+func (r *ReadOnlySwiftCodeRepository) Upsert(ctx context.Context, code *models.SwiftCode) error {
+	return ErrReadOnly
+}
+
+func (r *ReadOnlySwiftCodeRepository) Update(ctx context.Context, code *models.SwiftCode) error {
+	return ErrReadOnly
+}
+
+func (r *ReadOnlySwiftCodeRepository) ApplyChanges(ctx context.Context, upserts []models.SwiftCode, removals []string) error {
+	return ErrReadOnly
+}
+
+func (r *ReadOnlySwiftCodeRepository) Delete(ctx context.Context, swiftCode string) error {
+	return ErrReadOnly
+}
+
+func (r *ReadOnlySwiftCodeRepository) DeleteHeadquarter(ctx context.Context, swiftCode string, cascade bool) ([]string, error) {
+	return nil, ErrReadOnly
+}
+
+func (r *ReadOnlySwiftCodeRepository) Restore(ctx context.Context, swiftCode string) error {
+	return ErrReadOnly
+}
+
+func (r *ReadOnlySwiftCodeRepository) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
+	return 0, ErrReadOnly
+}
@@ -0,0 +1,37 @@
+package repositories
+
+import (
+	"RemitlyTask/src/models"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// SQLiteSwiftCodeRepository runs the queries of SwiftCodeRepository on SQLite.
+// SQLite has no pg_trgm, so searches are ranked in Go instead.
+type SQLiteSwiftCodeRepository struct {
+	*SwiftCodeRepository
+}
+
+func NewSQLiteSwiftCodeRepository(db *gorm.DB) ISwiftCodeRepository {
+	return &SQLiteSwiftCodeRepository{SwiftCodeRepository: &SwiftCodeRepository{db: db}}
+}
+
+func (r *SQLiteSwiftCodeRepository) Search(ctx context.Context, query string, iso2 string, limit int) ([]models.ScoredSwiftCode, error) {
+	results := []models.ScoredSwiftCode{}
+	search := newTrigramSearch(query)
+	if search.empty() {
+		return results, nil
+	}
+
+	err := r.Iterate(ctx, iso2, func(code models.SwiftCode) error {
+		if score, ok := search.score(code); ok {
+			results = append(results, models.ScoredSwiftCode{Code: code, Score: score})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rankSearchResults(results, limit), nil
+}
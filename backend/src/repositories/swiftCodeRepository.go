@@ -2,18 +2,35 @@ package repositories
 
 import (
 	"RemitlyTask/src/models"
+	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ISwiftCodeRepository interface {
-	FindBySwiftCodePrefix(prefix string) ([]models.SwiftCode, error)
-	FindBySwiftCode(code string) (models.SwiftCode, error)
-	FindCountryNameByISO2(iso2 string) (string, error)
-	FindByCountryISO2(iso2 string) ([]models.SwiftCode, error)
-	Create(newCode *models.SwiftCode) error
-	Delete(swiftCode string) error
+	FindBySwiftCodePrefix(ctx context.Context, prefix string) ([]models.SwiftCode, error)
+	FindBySwiftCode(ctx context.Context, code string) (models.SwiftCode, error)
+	FindByBankCodes(ctx context.Context, bankCodes []string) ([]models.SwiftCode, error)
+	FindCountryNameByISO2(ctx context.Context, iso2 string) (string, error)
+	FindByCountryISO2(ctx context.Context, iso2 string, query models.CountryQuery) ([]models.SwiftCode, error)
+	Search(ctx context.Context, query string, iso2 string, limit int) ([]models.ScoredSwiftCode, error)
+	Create(ctx context.Context, newCode *models.SwiftCode) error
+	CreateBatch(ctx context.Context, newCodes []*models.SwiftCode) error
+	Upsert(ctx context.Context, code *models.SwiftCode) error
+	Update(ctx context.Context, code *models.SwiftCode) error
+	FindAll(ctx context.Context) ([]models.SwiftCode, error)
+	Iterate(ctx context.Context, iso2 string, fn func(models.SwiftCode) error) error
+	ApplyChanges(ctx context.Context, upserts []models.SwiftCode, removals []string) error
+	Delete(ctx context.Context, swiftCode string) error
+	DeleteHeadquarter(ctx context.Context, swiftCode string, cascade bool) ([]string, error)
+	Restore(ctx context.Context, swiftCode string) error
+	Purge(ctx context.Context, deletedBefore time.Time) (int, error)
+	FindHistory(ctx context.Context, swiftCode string) ([]models.AuditEntry, error)
 }
 
 type SwiftCodeRepository struct {
@@ -24,41 +41,431 @@ func NewSwiftCodeRepository(db *gorm.DB) ISwiftCodeRepository {
 	return &SwiftCodeRepository{db: db}
 }
 
-func (r *SwiftCodeRepository) FindBySwiftCodePrefix(prefix string) ([]models.SwiftCode, error) {
+// read starts a query of r run with ctx. Queries as of a past time read the
+// versions valid at that time under the name of swift_codes.
+func (r *SwiftCodeRepository) read(ctx context.Context) *gorm.DB {
+	if asOf, ok := asOfFrom(ctx); ok {
+		return r.db.WithContext(ctx).Unscoped().
+			Table("swift_code_versions AS swift_codes").
+			Where("swift_codes.valid_from <= ? AND (swift_codes.valid_to IS NULL OR swift_codes.valid_to > ?)", asOf, asOf)
+	}
+	if includesDeleted(ctx) {
+		return r.db.WithContext(ctx).Unscoped()
+	}
+	return r.db.WithContext(ctx)
+}
+
+func (r *SwiftCodeRepository) FindBySwiftCodePrefix(ctx context.Context, prefix string) ([]models.SwiftCode, error) {
 	var swiftCodes []models.SwiftCode
-	result := r.db.Where("swift_code LIKE ?", prefix+"%").Find(&swiftCodes)
+	result := r.read(ctx).Where("swift_code LIKE ?", prefix+"%").Find(&swiftCodes)
 	return swiftCodes, result.Error
 }
 
-func (r *SwiftCodeRepository) FindBySwiftCode(code string) (models.SwiftCode, error) {
+func (r *SwiftCodeRepository) FindBySwiftCode(ctx context.Context, code string) (models.SwiftCode, error) {
 	var swiftCode models.SwiftCode
-	result := r.db.Where("swift_code = ?", code).Find(&swiftCode)
+	result := r.read(ctx).Where("swift_code = ?", code).Find(&swiftCode)
 	return swiftCode, result.Error
 }
 
-func (r *SwiftCodeRepository) FindCountryNameByISO2(iso2 string) (string, error) {
+// FindByBankCodes returns every code whose first 8 characters are one of
+// bankCodes, which covers both the codes themselves and their branches.
+func (r *SwiftCodeRepository) FindByBankCodes(ctx context.Context, bankCodes []string) ([]models.SwiftCode, error) {
+	var swiftCodes []models.SwiftCode
+	if len(bankCodes) == 0 {
+		return swiftCodes, nil
+	}
+	result := r.read(ctx).Where("SUBSTR(swift_code, 1, 8) IN ?", bankCodes).Order("swift_code").Find(&swiftCodes)
+	return swiftCodes, result.Error
+}
+
+func (r *SwiftCodeRepository) FindCountryNameByISO2(ctx context.Context, iso2 string) (string, error) {
 	var countryName string
-	result := r.db.Table("swift_codes").Select("country_name").Where("country_iso2 = ?", iso2).Scan(&countryName)
+	result := r.read(ctx).Model(&models.SwiftCode{}).Select("country_name").Where("country_iso2 = ?", iso2).Scan(&countryName)
 	return countryName, result.Error
 }
 
-func (r *SwiftCodeRepository) FindByCountryISO2(iso2 string) ([]models.SwiftCode, error) {
+var sortColumns = map[string]string{
+	models.SortBySwiftCode: "swift_code",
+	models.SortByBankName:  "name",
+	models.SortByTownName:  "COALESCE(town_name, '')",
+}
+
+func (r *SwiftCodeRepository) FindByCountryISO2(ctx context.Context, iso2 string, query models.CountryQuery) ([]models.SwiftCode, error) {
+	var swiftCodes []models.SwiftCode
+
+	column, ok := sortColumns[query.SortBy]
+	if !ok {
+		column = sortColumns[models.SortBySwiftCode]
+	}
+	direction, comparison := "ASC", ">"
+	if query.Descending {
+		direction, comparison = "DESC", "<"
+	}
+
+	tx := r.read(ctx).Where("country_iso2 = ?", iso2)
+	if query.IsHeadquarter != nil {
+		if *query.IsHeadquarter {
+			tx = tx.Where("swift_code LIKE ?", "%XXX")
+		} else {
+			tx = tx.Where("swift_code NOT LIKE ?", "%XXX")
+		}
+	}
+	if query.Town != "" {
+		tx = tx.Where("UPPER(town_name) = UPPER(?)", query.Town)
+	}
+
+	if column == sortColumns[models.SortBySwiftCode] {
+		if query.After != nil {
+			tx = tx.Where("swift_code "+comparison+" ?", query.After.SwiftCode)
+		}
+		tx = tx.Order("swift_code " + direction)
+	} else {
+		if query.After != nil {
+			tx = tx.Where("("+column+", swift_code) "+comparison+" (?, ?)", query.After.Value, query.After.SwiftCode)
+		}
+		tx = tx.Order(column + " " + direction).Order("swift_code " + direction)
+	}
+
+	if query.Limit > 0 {
+		tx = tx.Limit(query.Limit)
+	}
+
+	result := tx.Find(&swiftCodes)
+	return swiftCodes, result.Error
+}
+
+// searchDocument must stay identical to the expression of swift_codes_search_idx
+// in the Postgres migrations, otherwise searches fall back to a sequential scan.
+const searchDocument = "(name || ' ' || COALESCE(address, '') || ' ' || COALESCE(town_name, ''))"
+
+// Search ranks codes by how well every word of the query matches a word in the
+// bank name, address or town, tolerating typos and partial words.
+func (r *SwiftCodeRepository) Search(ctx context.Context, query string, iso2 string, limit int) ([]models.ScoredSwiftCode, error) {
+	var results []models.ScoredSwiftCode
+
+	words := strings.Fields(query)
+	if len(words) == 0 {
+		return results, nil
+	}
+
+	scores := make([]string, len(words))
+	args := make([]interface{}, 0, len(words)+1)
+	for i, word := range words {
+		scores[i] = "word_similarity(?, " + searchDocument + ")"
+		args = append(args, word)
+	}
+	args = append(args, len(words))
+
+	tx := r.read(ctx).Model(&models.SwiftCode{}).
+		Select("swift_codes.*, ("+strings.Join(scores, " + ")+") / ? AS score", args...)
+	for _, word := range words {
+		tx = tx.Where("? <% "+searchDocument, word)
+	}
+	if iso2 != "" {
+		tx = tx.Where("country_iso2 = ?", iso2)
+	}
+
+	result := tx.Order("score DESC").Order("swift_code").Limit(limit).Scan(&results)
+	return results, result.Error
+}
+
+func (r *SwiftCodeRepository) Create(ctx context.Context, newCode *models.SwiftCode) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(newCode).Error; err != nil {
+			return err
+		}
+		entry := auditEntry(ctx, models.AuditCreate, newCode.SwiftCode, nil, newCode)
+		return recordEntries(tx, entry)
+	})
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		var stored models.SwiftCode
+		if err := r.db.WithContext(ctx).Unscoped().Where("swift_code = ?", newCode.SwiftCode).Find(&stored).Error; err != nil {
+			return err
+		}
+		return existsError(stored)
+	}
+	return err
+}
+
+func (r *SwiftCodeRepository) CreateBatch(ctx context.Context, newCodes []*models.SwiftCode) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.CreateInBatches(newCodes, 500).Error; err != nil {
+			return err
+		}
+		entries := make([]models.AuditEntry, len(newCodes))
+		for i, newCode := range newCodes {
+			entries[i] = auditEntry(ctx, models.AuditCreate, newCode.SwiftCode, nil, newCode)
+		}
+		return recordEntries(tx, entries...)
+	})
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return fmt.Errorf("a SWIFT code of the batch %w", models.ErrAlreadyExists)
+	}
+	return err
+}
+
+// Upsert creates code or replaces the stored one. A deleted code is restored,
+// which its audit entry records.
+func (r *SwiftCodeRepository) Upsert(ctx context.Context, code *models.SwiftCode) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		stored, err := lockCodes(tx.Unscoped(), []string{code.SwiftCode})
+		if err != nil {
+			return err
+		}
+		if err := tx.Clauses(upsertBySwiftCode()).Create(code).Error; err != nil {
+			return err
+		}
+		previous, exists := stored[code.SwiftCode]
+		if entry, ok := upsertEntry(ctx, *code, previous, exists); ok {
+			return recordEntries(tx, entry)
+		}
+		return nil
+	})
+}
+
+func (r *SwiftCodeRepository) Update(ctx context.Context, code *models.SwiftCode) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		stored, err := lockCodes(tx, []string{code.SwiftCode})
+		if err != nil {
+			return err
+		}
+		before, ok := stored[code.SwiftCode]
+		if !ok {
+			return fmt.Errorf("SWIFT code %s %w", code.SwiftCode, models.ErrNotFound)
+		}
+
+		err = tx.Model(&models.SwiftCode{}).Where("swift_code = ?", code.SwiftCode).Updates(map[string]interface{}{
+			"address":   code.Address,
+			"name":      code.Name,
+			"town_name": code.TownName,
+			"time_zone": code.TimeZone,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		after := before
+		after.Address, after.Name, after.TownName, after.TimeZone = code.Address, code.Name, code.TownName, code.TimeZone
+		entry := auditEntry(ctx, models.AuditUpdate, code.SwiftCode, &before, &after)
+		return recordEntries(tx, entry)
+	})
+}
+
+func (r *SwiftCodeRepository) FindAll(ctx context.Context) ([]models.SwiftCode, error) {
 	var swiftCodes []models.SwiftCode
-	result := r.db.Where("country_iso2 = ?", iso2).Find(&swiftCodes)
+	result := r.read(ctx).Order("swift_code").Find(&swiftCodes)
 	return swiftCodes, result.Error
 }
 
-func (r *SwiftCodeRepository) Create(newCode *models.SwiftCode) error {
-	return r.db.Create(newCode).Error
+// Iterate calls fn for every code, optionally of a single country, ordered by
+// SWIFT code. Rows are read one at a time and iteration stops at the first
+// error returned by fn.
+func (r *SwiftCodeRepository) Iterate(ctx context.Context, iso2 string, fn func(models.SwiftCode) error) error {
+	query := r.read(ctx).Model(&models.SwiftCode{}).Order("swift_code")
+	if iso2 != "" {
+		query = query.Where("country_iso2 = ?", iso2)
+	}
+
+	rows, err := query.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var code models.SwiftCode
+		if err := r.db.ScanRows(rows, &code); err != nil {
+			return err
+		}
+		if err := fn(code); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// ApplyChanges deletes removals and upserts upserts in one transaction, like
+// Delete and Upsert.
+func (r *SwiftCodeRepository) ApplyChanges(ctx context.Context, upserts []models.SwiftCode, removals []string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		swiftCodes := append([]string{}, removals...)
+		for _, code := range upserts {
+			swiftCodes = append(swiftCodes, code.SwiftCode)
+		}
+		stored, err := lockCodes(tx.Unscoped(), swiftCodes)
+		if err != nil {
+			return err
+		}
+
+		var entries []models.AuditEntry
+		if len(removals) > 0 {
+			if err := tx.Where("swift_code IN ?", removals).Delete(&models.SwiftCode{}).Error; err != nil {
+				return err
+			}
+			for _, swiftCode := range removals {
+				if before, ok := stored[swiftCode]; ok && !before.DeletedAt.Valid {
+					entries = append(entries, auditEntry(ctx, models.AuditDelete, swiftCode, &before, nil))
+					before.DeletedAt = gorm.DeletedAt{Valid: true}
+					stored[swiftCode] = before
+				}
+			}
+		}
+		if len(upserts) > 0 {
+			if err := tx.Clauses(upsertBySwiftCode()).CreateInBatches(upserts, 500).Error; err != nil {
+				return err
+			}
+			for _, code := range upserts {
+				previous, exists := stored[code.SwiftCode]
+				if entry, ok := upsertEntry(ctx, code, previous, exists); ok {
+					entries = append(entries, entry)
+				}
+			}
+		}
+		return recordEntries(tx, entries...)
+	})
 }
 
-func (r *SwiftCodeRepository) Delete(swiftCode string) error {
-	result := r.db.Where("swift_code = ?", swiftCode).Delete(&models.SwiftCode{})
-	if result.Error != nil {
-		return result.Error
+func upsertBySwiftCode() clause.OnConflict {
+	return clause.OnConflict{
+		Columns:   []clause.Column{{Name: "swift_code"}},
+		DoUpdates: clause.AssignmentColumns([]string{"country_iso2", "code_type", "name", "address", "town_name", "country_name", "time_zone", "deleted_at"}),
+	}
+}
+
+func (r *SwiftCodeRepository) Delete(ctx context.Context, swiftCode string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		stored, err := lockCodes(tx, []string{swiftCode})
+		if err != nil {
+			return err
+		}
+		before, ok := stored[swiftCode]
+		if !ok {
+			return fmt.Errorf("SWIFT code %s %w", swiftCode, models.ErrNotFound)
+		}
+		if err := tx.Where("swift_code = ?", swiftCode).Delete(&models.SwiftCode{}).Error; err != nil {
+			return err
+		}
+		entry := auditEntry(ctx, models.AuditDelete, swiftCode, &before, nil)
+		return recordEntries(tx, entry)
+	})
+}
+
+// DeleteHeadquarter deletes a headquarter and returns the deleted codes,
+// sorted. The codes of its bank are locked first, so that no branch is added or
+// removed between the check for branches and the deletion. A headquarter with
+// branches is refused with models.ErrConflict, unless cascade is set, which
+// deletes the branches along with it.
+func (r *SwiftCodeRepository) DeleteHeadquarter(ctx context.Context, swiftCode string, cascade bool) ([]string, error) {
+	var removed []string
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var codes []models.SwiftCode
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("swift_code LIKE ?", swiftCode[:8]+"%").
+			Order("swift_code").
+			Find(&codes).Error
+		if err != nil {
+			return err
+		}
+		if err := checkBranches(swiftCode, codes, cascade); err != nil {
+			return err
+		}
+
+		removed = make([]string, len(codes))
+		entries := make([]models.AuditEntry, len(codes))
+		for i, code := range codes {
+			removed[i] = code.SwiftCode
+			entries[i] = auditEntry(ctx, models.AuditDelete, code.SwiftCode, &code, nil)
+		}
+		for start := 0; start < len(removed); start += 500 {
+			err := tx.Where("swift_code IN ?", removed[start:min(start+500, len(removed))]).Delete(&models.SwiftCode{}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return recordEntries(tx, entries...)
+	})
+	if err != nil {
+		return nil, err
 	}
-	if result.RowsAffected == 0 {
-		return fmt.Errorf("SWIFT code %s not found", swiftCode)
+	return removed, nil
+}
+
+// Restore undoes the deletion of a code that hasn't been purged yet.
+func (r *SwiftCodeRepository) Restore(ctx context.Context, swiftCode string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		stored, err := lockCodes(tx.Unscoped(), []string{swiftCode})
+		if err != nil {
+			return err
+		}
+		code, ok := stored[swiftCode]
+		if !ok {
+			return fmt.Errorf("SWIFT code %s %w", swiftCode, models.ErrNotFound)
+		}
+		if !code.DeletedAt.Valid {
+			return fmt.Errorf("SWIFT code %s is not deleted: %w", swiftCode, models.ErrConflict)
+		}
+		if err := tx.Unscoped().Model(&code).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		entry := auditEntry(ctx, models.AuditRestore, swiftCode, nil, &code)
+		return recordEntries(tx, entry)
+	})
+}
+
+// Purge removes the codes deleted before deletedBefore for good and returns
+// how many there were.
+func (r *SwiftCodeRepository) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
+	var purged int
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var codes []models.SwiftCode
+		err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("deleted_at < ?", deletedBefore).
+			Find(&codes).Error
+		if err != nil || len(codes) == 0 {
+			return err
+		}
+
+		ids := make([]uint, len(codes))
+		entries := make([]models.AuditEntry, len(codes))
+		for i, code := range codes {
+			ids[i] = code.ID
+			entries[i] = auditEntry(ctx, models.AuditPurge, code.SwiftCode, &code, nil)
+		}
+		for start := 0; start < len(ids); start += 500 {
+			err := tx.Unscoped().Where("id IN ?", ids[start:min(start+500, len(ids))]).Delete(&models.SwiftCode{}).Error
+			if err != nil {
+				return err
+			}
+		}
+		purged = len(codes)
+		return recordEntries(tx, entries...)
+	})
+	return purged, err
+}
+
+// FindHistory returns the audit entries of a code, oldest first.
+func (r *SwiftCodeRepository) FindHistory(ctx context.Context, swiftCode string) ([]models.AuditEntry, error) {
+	var entries []models.AuditEntry
+	result := r.db.WithContext(ctx).Where("swift_code = ?", swiftCode).Order("id").Find(&entries)
+	return entries, result.Error
+}
+
+// lockCodes reads the stored codes among swiftCodes and locks their rows until
+// tx ends, so the audit entries of a write record what it replaced. SQLite
+// locks the whole database for the transaction instead.
+func lockCodes(tx *gorm.DB, swiftCodes []string) (map[string]models.SwiftCode, error) {
+	stored := make(map[string]models.SwiftCode, len(swiftCodes))
+	for start := 0; start < len(swiftCodes); start += 500 {
+		var codes []models.SwiftCode
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("swift_code IN ?", swiftCodes[start:min(start+500, len(swiftCodes))]).
+			Find(&codes).Error
+		if err != nil {
+			return nil, err
+		}
+		for _, code := range codes {
+			stored[code.SwiftCode] = code
+		}
 	}
-	return nil
+	return stored, nil
 }
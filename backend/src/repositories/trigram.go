@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"RemitlyTask/src/models"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// wordSimilarityThreshold is the default pg_trgm.word_similarity_threshold
+// used by the <% operator of the Postgres search.
+const wordSimilarityThreshold = 0.6
+
+// trigramSearch ranks codes in Go for the backends without pg_trgm. It follows
+// the Postgres search: every query word has to be similar to a word of the
+// bank name, address or town, and the score is the average similarity.
+type trigramSearch struct {
+	words []map[string]struct{}
+}
+
+func newTrigramSearch(query string) *trigramSearch {
+	s := &trigramSearch{}
+	for _, word := range strings.Fields(query) {
+		for _, part := range splitWords(word) {
+			s.words = append(s.words, trigrams(part))
+		}
+	}
+	return s
+}
+
+func (s *trigramSearch) empty() bool {
+	return len(s.words) == 0
+}
+
+func (s *trigramSearch) score(code models.SwiftCode) (float64, bool) {
+	var docWords []map[string]struct{}
+	for _, word := range splitWords(code.Name + " " + code.Address + " " + code.TownName) {
+		docWords = append(docWords, trigrams(word))
+	}
+
+	total := 0.0
+	for _, queryWord := range s.words {
+		best := 0.0
+		for _, docWord := range docWords {
+			if similarity := wordSimilarity(queryWord, docWord); similarity > best {
+				best = similarity
+			}
+		}
+		if best < wordSimilarityThreshold {
+			return 0, false
+		}
+		total += best
+	}
+	return total / float64(len(s.words)), true
+}
+
+// rankSearchResults orders results like the Postgres search and applies limit.
+func rankSearchResults(results []models.ScoredSwiftCode, limit int) []models.ScoredSwiftCode {
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Code.SwiftCode < results[j].Code.SwiftCode
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// wordSimilarity is the share of the query word's trigrams found in a
+// document word.
+func wordSimilarity(queryWord, docWord map[string]struct{}) float64 {
+	if len(queryWord) == 0 {
+		return 0
+	}
+	shared := 0
+	for trigram := range queryWord {
+		if _, ok := docWord[trigram]; ok {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(queryWord))
+}
+
+// trigrams of a word padded the way pg_trgm pads it, two spaces in front and
+// one behind.
+func trigrams(word string) map[string]struct{} {
+	runes := []rune("  " + strings.ToLower(word) + " ")
+	set := make(map[string]struct{}, len(runes))
+	for i := 0; i+3 <= len(runes); i++ {
+		set[string(runes[i:i+3])] = struct{}{}
+	}
+	return set
+}
+
+func splitWords(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"RemitlyTask/src/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type asOfKey struct{}
+
+// AsOf makes the queries run with ctx answer from the versions of the codes
+// that were current at t rather than from the codes as they are now.
+func AsOf(ctx context.Context, t time.Time) context.Context {
+	return context.WithValue(ctx, asOfKey{}, t.UTC())
+}
+
+func asOfFrom(ctx context.Context) (time.Time, bool) {
+	asOf, ok := ctx.Value(asOfKey{}).(time.Time)
+	return asOf, ok
+}
+
+// recordEntries writes the audit entries of a write made in tx and the
+// versions they close and open. The entries of a write share its time.
+func recordEntries(tx *gorm.DB, entries ...models.AuditEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	swiftCodes := make([]string, len(entries))
+	var versions []models.SwiftCodeVersion
+	for i := range entries {
+		entries[i].Timestamp = now
+		swiftCodes[i] = entries[i].SwiftCode
+		if entries[i].After != nil {
+			versions = append(versions, models.NewSwiftCodeVersion(entries[i].SwiftCode, *entries[i].After, now))
+		}
+	}
+
+	if err := tx.CreateInBatches(entries, 500).Error; err != nil {
+		return err
+	}
+	for start := 0; start < len(swiftCodes); start += 500 {
+		err := tx.Model(&models.SwiftCodeVersion{}).
+			Where("swift_code IN ? AND valid_to IS NULL", swiftCodes[start:min(start+500, len(swiftCodes))]).
+			Update("valid_to", now).Error
+		if err != nil {
+			return err
+		}
+	}
+	if len(versions) == 0 {
+		return nil
+	}
+	return tx.CreateInBatches(versions, 500).Error
+}
@@ -0,0 +1,67 @@
+// Package router registers the routes of the API. The server and the
+// integration tests share it, and src/openapi/openapi.json documents every
+// route registered here.
+package router
+
+import (
+	"RemitlyTask/src/handlers"
+	"RemitlyTask/src/proxy"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Options are the settings the routes depend on. ReadOnly rejects the write
+// routes with 405. TrustedProxies are the IPs or CIDR ranges of the proxies
+// whose X-Forwarded-For and X-Actor headers are trusted, none when empty.
+type Options struct {
+	ReadOnly           bool
+	LegacyErrorMessage bool
+	RequestTimeout     time.Duration
+	TrustedProxies     []string
+}
+
+// New panics when a trusted proxy is neither an IP nor a CIDR range, which
+// config.Validate rules out.
+func New(handler *handlers.SwiftCodeHandler, opts Options) *gin.Engine {
+	trusted, err := proxy.Parse(opts.TrustedProxies)
+	if err != nil {
+		panic(err)
+	}
+	r := gin.Default()
+	if err := r.SetTrustedProxies(opts.TrustedProxies); err != nil {
+		panic(err)
+	}
+	r.Use(handlers.ErrorHandler(opts.LegacyErrorMessage), handlers.Actor(trusted), handlers.Timeout(opts.RequestTimeout))
+
+	r.GET("/openapi.json", handlers.OpenAPISpec)
+	r.GET("/docs/*file", handlers.SwaggerUI)
+	r.GET("/graphql", handler.GraphQL)
+	r.POST("/graphql", handler.GraphQL)
+
+	vCodes := r.Group("v1/swift-codes")
+	{
+		vCodes.GET("/:swift-code", handler.GetCode)
+		vCodes.GET("/:swift-code/history", handler.GetCodeHistory)
+		vCodes.GET("/country/:ISO2", handler.GetCodesByCountry)
+		vCodes.GET("/search", handler.SearchCodes)
+		vCodes.GET("/suggest", handler.SuggestCodes)
+		vCodes.GET("/export", handler.ExportCodes)
+		vCodes.POST("/lookup", handler.LookupCodes)
+	}
+
+	writes := vCodes.Group("")
+	if opts.ReadOnly {
+		writes.Use(handlers.ReadOnly(r))
+	}
+	{
+		writes.POST("/bulk", handler.BulkAddSwiftCodes)
+		writes.POST("", handler.AddNewSwiftCode)
+		writes.PUT("/:swift-code", handler.ReplaceCode)
+		writes.PATCH("/:swift-code", handler.PatchCode)
+		writes.DELETE("/:swift-code", handler.DeleteCode)
+		writes.POST("/:swift-code/restore", handler.RestoreCode)
+	}
+
+	return r
+}
@@ -0,0 +1,83 @@
+package rpc
+
+import (
+	"RemitlyTask/src/models"
+	"RemitlyTask/src/repositories"
+	"RemitlyTask/src/validation"
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// statusError turns the domain error wrapped by err into a gRPC status, the
+// same way the REST API maps it to an HTTP status. Invalid fields are listed
+// in a BadRequest detail.
+func statusError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	code := codes.Internal
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		code = codes.DeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		code = codes.Canceled
+	case errors.Is(err, models.ErrValidation):
+		code = codes.InvalidArgument
+	case errors.Is(err, models.ErrNotFound):
+		code = codes.NotFound
+	case errors.Is(err, models.ErrAlreadyExists):
+		code = codes.AlreadyExists
+	case errors.Is(err, models.ErrConflict), errors.Is(err, repositories.ErrReadOnly):
+		code = codes.FailedPrecondition
+	}
+
+	message := err.Error()
+	if code == codes.Internal {
+		message = "internal error"
+	}
+	st := status.New(code, message)
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, fieldErr := range validation.FieldErrors(err) {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       fieldErr.Field,
+			Description: fieldErr.Err.Error(),
+		})
+	}
+	if len(violations) > 0 {
+		if detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations}); err == nil {
+			st = detailed
+		}
+	}
+	return st.Err()
+}
+
+func unaryErrors(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	resp, err := handler(ctx, req)
+	return resp, statusError(err)
+}
+
+func streamErrors(srv any, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return statusError(handler(srv, stream))
+}
+
+func unaryTimeout(timeout time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if timeout <= 0 {
+			return handler(ctx, req)
+		}
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,229 @@
+// Package rpc serves the operations of the REST API over gRPC, on top of the
+// same ISwiftCodeService. The messages and service are defined in
+// proto/swift_codes.proto; swiftcodespb is generated from it with buf.
+package rpc
+
+//go:generate buf generate proto
+
+import (
+	"RemitlyTask/src/models"
+	"RemitlyTask/src/proxy"
+	"RemitlyTask/src/repositories"
+	"RemitlyTask/src/rpc/swiftcodespb"
+	"RemitlyTask/src/services"
+	"RemitlyTask/src/validation"
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// listPageSize is the number of codes ListByCountry reads from the service at
+// a time.
+const listPageSize = 500
+
+// Options are the settings of the gRPC server. RequestTimeout bounds every
+// unary call like the request timeout of the REST API, and each page query of
+// a stream, 0 for none. The x-actor metadata is trusted from TrustedProxies
+// only, as the X-Actor header is by the REST API.
+type Options struct {
+	RequestTimeout time.Duration
+	TrustedProxies []string
+}
+
+type Server struct {
+	swiftcodespb.UnimplementedSwiftCodesServer
+	service        services.ISwiftCodeService
+	requestTimeout time.Duration
+}
+
+// NewServer panics when a trusted proxy is neither an IP nor a CIDR range,
+// which config.Validate rules out.
+func NewServer(service services.ISwiftCodeService, opts Options) *grpc.Server {
+	trusted, err := proxy.Parse(opts.TrustedProxies)
+	if err != nil {
+		panic(err)
+	}
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryTimeout(opts.RequestTimeout), unaryActor(trusted), unaryErrors),
+		grpc.ChainStreamInterceptor(streamErrors),
+	)
+	swiftcodespb.RegisterSwiftCodesServer(s, &Server{service: service, requestTimeout: opts.RequestTimeout})
+	return s
+}
+
+// unaryActor attributes the writes of a call to its peer address, with the
+// x-request-id metadata as request ID, like the headers of the REST API. The
+// x-actor metadata names the actor when the peer is a trusted proxy, and is
+// recorded as the claimed actor otherwise.
+func unaryActor(trusted proxy.Trusted) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		var actor repositories.Actor
+		if p, ok := peer.FromContext(ctx); ok {
+			actor.Name = p.Addr.String()
+		}
+		md, _ := metadata.FromIncomingContext(ctx)
+		if values := md.Get("x-actor"); len(values) > 0 {
+			if trusted.Contains(actor.Name) {
+				actor.Name = values[0]
+			} else {
+				actor.Claimed = values[0]
+			}
+		}
+		if values := md.Get("x-request-id"); len(values) > 0 {
+			actor.RequestID = values[0]
+		}
+		return handler(repositories.WithActor(ctx, actor), req)
+	}
+}
+
+func (s *Server) GetCode(ctx context.Context, req *swiftcodespb.GetCodeRequest) (*swiftcodespb.SwiftCodeDetails, error) {
+	bic, err := validation.ParseBIC(req.GetSwiftCode())
+	if err != nil {
+		return nil, err
+	}
+
+	if !bic.IsHeadquarter() {
+		branch, err := s.service.GetBranchDetails(ctx, req.GetSwiftCode())
+		if err != nil {
+			return nil, err
+		}
+		return toBranchDetails(branch.(models.SwiftCodeBranch)), nil
+	}
+
+	headquarter, err := s.service.GetHeadquarterDetails(ctx, bic.InstitutionCode+bic.CountryCode+bic.LocationCode)
+	if err != nil {
+		return nil, err
+	}
+	return toHeadquarterDetails(headquarter.(models.SwiftCodeDetails)), nil
+}
+
+func (s *Server) ListByCountry(req *swiftcodespb.ListByCountryRequest, stream grpc.ServerStreamingServer[swiftcodespb.SwiftCode]) error {
+	iso2 := strings.ToUpper(req.GetCountryIso2())
+	if len(iso2) != 2 {
+		return &validation.FieldError{Field: validation.FieldCountryISO2, Value: req.GetCountryIso2(), Err: validation.ErrInvalidISO2}
+	}
+
+	query := models.CountryQuery{
+		Limit:         listPageSize,
+		SortBy:        sortBy(req.GetSortBy()),
+		Descending:    req.GetDescending(),
+		IsHeadquarter: req.IsHeadquarter,
+		Town:          req.GetTown(),
+	}
+	for {
+		page, err := s.countryPage(stream.Context(), iso2, query)
+		if err != nil {
+			return err
+		}
+		for _, code := range page.SwiftCodes {
+			if err := stream.Send(toSwiftCode(code)); err != nil {
+				return err
+			}
+		}
+
+		if page.NextCursor == "" {
+			return nil
+		}
+		after, err := models.DecodeCursor(page.NextCursor)
+		if err != nil {
+			return err
+		}
+		query.After = &after
+	}
+}
+
+// countryPage reads a page of ListByCountry. The request timeout applies to each
+// page rather than the whole stream, so that a slow client doesn't make a long
+// stream fail partway through.
+func (s *Server) countryPage(ctx context.Context, iso2 string, query models.CountryQuery) (models.SwiftCodeCountry, error) {
+	if s.requestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.requestTimeout)
+		defer cancel()
+	}
+	response, err := s.service.GetSwiftCodesByCountry(ctx, iso2, query)
+	if err != nil {
+		return models.SwiftCodeCountry{}, err
+	}
+	return response.(models.SwiftCodeCountry), nil
+}
+
+func (s *Server) Create(ctx context.Context, req *swiftcodespb.CreateRequest) (*swiftcodespb.CreateResponse, error) {
+	newCode := models.SwiftCodeBranch{
+		Address:       req.GetAddress(),
+		BankName:      req.GetBankName(),
+		CountryISO2:   req.GetCountryIso2(),
+		CountryName:   req.GetCountryName(),
+		IsHeadquarter: req.GetIsHeadquarter(),
+		SwiftCode:     req.GetSwiftCode(),
+	}
+	validCode, err := s.service.ValidateNewSwiftCode(ctx, newCode)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.service.AddSwiftCode(ctx, &validCode); err != nil {
+		return nil, err
+	}
+	return &swiftcodespb.CreateResponse{SwiftCode: newCode.SwiftCode}, nil
+}
+
+func (s *Server) Delete(ctx context.Context, req *swiftcodespb.DeleteRequest) (*swiftcodespb.DeleteResponse, error) {
+	if _, err := validation.ParseBIC(req.GetSwiftCode()); err != nil {
+		return nil, err
+	}
+	if err := s.service.DeleteSwiftCode(ctx, req.GetSwiftCode()); err != nil {
+		return nil, err
+	}
+	return &swiftcodespb.DeleteResponse{SwiftCode: req.GetSwiftCode()}, nil
+}
+
+func sortBy(sort swiftcodespb.ListByCountryRequest_SortBy) string {
+	switch sort {
+	case swiftcodespb.ListByCountryRequest_SORT_BY_BANK_NAME:
+		return models.SortByBankName
+	case swiftcodespb.ListByCountryRequest_SORT_BY_TOWN_NAME:
+		return models.SortByTownName
+	default:
+		return models.SortBySwiftCode
+	}
+}
+
+func toSwiftCode(code models.SwiftCodeBank) *swiftcodespb.SwiftCode {
+	return &swiftcodespb.SwiftCode{
+		Address:       code.Address,
+		BankName:      code.BankName,
+		CountryIso2:   code.CountryISO2,
+		IsHeadquarter: code.IsHeadquarter,
+		SwiftCode:     code.SwiftCode,
+	}
+}
+
+func toBranchDetails(branch models.SwiftCodeBranch) *swiftcodespb.SwiftCodeDetails {
+	return &swiftcodespb.SwiftCodeDetails{
+		Address:       branch.Address,
+		BankName:      branch.BankName,
+		CountryIso2:   branch.CountryISO2,
+		CountryName:   branch.CountryName,
+		IsHeadquarter: branch.IsHeadquarter,
+		SwiftCode:     branch.SwiftCode,
+	}
+}
+
+func toHeadquarterDetails(headquarter models.SwiftCodeDetails) *swiftcodespb.SwiftCodeDetails {
+	details := &swiftcodespb.SwiftCodeDetails{
+		Address:       headquarter.Address,
+		BankName:      headquarter.BankName,
+		CountryIso2:   headquarter.CountryISO2,
+		CountryName:   headquarter.CountryName,
+		IsHeadquarter: headquarter.IsHeadquarter,
+		SwiftCode:     headquarter.SwiftCode,
+	}
+	for _, branch := range headquarter.Branches {
+		details.Branches = append(details.Branches, toSwiftCode(branch))
+	}
+	return details
+}
@@ -0,0 +1,249 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: swift_codes.proto
+
+package swiftcodespb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SwiftCodes_GetCode_FullMethodName       = "/swiftcodes.v1.SwiftCodes/GetCode"
+	SwiftCodes_ListByCountry_FullMethodName = "/swiftcodes.v1.SwiftCodes/ListByCountry"
+	SwiftCodes_Create_FullMethodName        = "/swiftcodes.v1.SwiftCodes/Create"
+	SwiftCodes_Delete_FullMethodName        = "/swiftcodes.v1.SwiftCodes/Delete"
+)
+
+// SwiftCodesClient is the client API for SwiftCodes service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// SwiftCodes mirrors the operations of the REST API at /v1/swift-codes.
+type SwiftCodesClient interface {
+	// GetCode returns the details of a SWIFT code. Headquarters, whose codes end
+	// with XXX, are returned with their branches.
+	GetCode(ctx context.Context, in *GetCodeRequest, opts ...grpc.CallOption) (*SwiftCodeDetails, error)
+	// ListByCountry streams every code of a country in the requested order.
+	ListByCountry(ctx context.Context, in *ListByCountryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SwiftCode], error)
+	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error)
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+}
+
+type swiftCodesClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSwiftCodesClient(cc grpc.ClientConnInterface) SwiftCodesClient {
+	return &swiftCodesClient{cc}
+}
+
+func (c *swiftCodesClient) GetCode(ctx context.Context, in *GetCodeRequest, opts ...grpc.CallOption) (*SwiftCodeDetails, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SwiftCodeDetails)
+	err := c.cc.Invoke(ctx, SwiftCodes_GetCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swiftCodesClient) ListByCountry(ctx context.Context, in *ListByCountryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SwiftCode], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SwiftCodes_ServiceDesc.Streams[0], SwiftCodes_ListByCountry_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListByCountryRequest, SwiftCode]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SwiftCodes_ListByCountryClient = grpc.ServerStreamingClient[SwiftCode]
+
+func (c *swiftCodesClient) Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateResponse)
+	err := c.cc.Invoke(ctx, SwiftCodes_Create_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swiftCodesClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, SwiftCodes_Delete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SwiftCodesServer is the server API for SwiftCodes service.
+// All implementations must embed UnimplementedSwiftCodesServer
+// for forward compatibility.
+//
+// SwiftCodes mirrors the operations of the REST API at /v1/swift-codes.
+type SwiftCodesServer interface {
+	// GetCode returns the details of a SWIFT code. Headquarters, whose codes end
+	// with XXX, are returned with their branches.
+	GetCode(context.Context, *GetCodeRequest) (*SwiftCodeDetails, error)
+	// ListByCountry streams every code of a country in the requested order.
+	ListByCountry(*ListByCountryRequest, grpc.ServerStreamingServer[SwiftCode]) error
+	Create(context.Context, *CreateRequest) (*CreateResponse, error)
+	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	mustEmbedUnimplementedSwiftCodesServer()
+}
+
+// UnimplementedSwiftCodesServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSwiftCodesServer struct{}
+
+func (UnimplementedSwiftCodesServer) GetCode(context.Context, *GetCodeRequest) (*SwiftCodeDetails, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCode not implemented")
+}
+func (UnimplementedSwiftCodesServer) ListByCountry(*ListByCountryRequest, grpc.ServerStreamingServer[SwiftCode]) error {
+	return status.Errorf(codes.Unimplemented, "method ListByCountry not implemented")
+}
+func (UnimplementedSwiftCodesServer) Create(context.Context, *CreateRequest) (*CreateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedSwiftCodesServer) Delete(context.Context, *DeleteRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedSwiftCodesServer) mustEmbedUnimplementedSwiftCodesServer() {}
+func (UnimplementedSwiftCodesServer) testEmbeddedByValue()                    {}
+
+// UnsafeSwiftCodesServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SwiftCodesServer will
+// result in compilation errors.
+type UnsafeSwiftCodesServer interface {
+	mustEmbedUnimplementedSwiftCodesServer()
+}
+
+func RegisterSwiftCodesServer(s grpc.ServiceRegistrar, srv SwiftCodesServer) {
+	// If the following call pancis, it indicates UnimplementedSwiftCodesServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SwiftCodes_ServiceDesc, srv)
+}
+
+func _SwiftCodes_GetCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SwiftCodesServer).GetCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SwiftCodes_GetCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SwiftCodesServer).GetCode(ctx, req.(*GetCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SwiftCodes_ListByCountry_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListByCountryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SwiftCodesServer).ListByCountry(m, &grpc.GenericServerStream[ListByCountryRequest, SwiftCode]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SwiftCodes_ListByCountryServer = grpc.ServerStreamingServer[SwiftCode]
+
+func _SwiftCodes_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SwiftCodesServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SwiftCodes_Create_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SwiftCodesServer).Create(ctx, req.(*CreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SwiftCodes_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SwiftCodesServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SwiftCodes_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SwiftCodesServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SwiftCodes_ServiceDesc is the grpc.ServiceDesc for SwiftCodes service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SwiftCodes_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "swiftcodes.v1.SwiftCodes",
+	HandlerType: (*SwiftCodesServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetCode",
+			Handler:    _SwiftCodes_GetCode_Handler,
+		},
+		{
+			MethodName: "Create",
+			Handler:    _SwiftCodes_Create_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _SwiftCodes_Delete_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListByCountry",
+			Handler:       _SwiftCodes_ListByCountry_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "swift_codes.proto",
+}
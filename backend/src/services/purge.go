@@ -0,0 +1,38 @@
+package services
+
+import (
+	"RemitlyTask/src/repositories"
+	"context"
+	"log/slog"
+	"time"
+)
+
+// PurgeActor is recorded as the actor of the purges made by RunPurger.
+const PurgeActor = "purge"
+
+// RunPurger purges the codes deleted more than retention ago every interval,
+// until ctx is done. Failed purges are logged and retried at the next tick.
+func RunPurger(ctx context.Context, service ISwiftCodeService, interval, retention time.Duration) {
+	ctx = repositories.WithActor(ctx, repositories.Actor{Name: PurgeActor})
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		purged, err := service.PurgeDeletedSwiftCodes(ctx, retention)
+		if err != nil {
+			if ctx.Err() == nil {
+				slog.Error("Error purging deleted swift codes", "error", err)
+			}
+			continue
+		}
+		if purged > 0 {
+			slog.Info("Purged deleted swift codes", "purged", purged, "retention", retention)
+		}
+	}
+}
@@ -3,40 +3,73 @@ package services
 import (
 	"RemitlyTask/src/models"
 	"RemitlyTask/src/repositories"
+	"RemitlyTask/src/suggest"
+	"RemitlyTask/src/validation"
+	"context"
+	"fmt"
 	"strings"
+	"sync"
+	"time"
 )
 
 type ISwiftCodeService interface {
-	GetHeadquarterDetails(swiftCodePrefix string) (interface{}, error)
-	GetBranchDetails(swiftCode string) (interface{}, error)
-	GetSwiftCodesByCountry(iso2 string) (interface{}, error)
-	AddSwiftCode(newCode *models.SwiftCode) error
-	DeleteSwiftCode(swiftCode string) error
-	GetCountryName(iso2 string) (string, error)
+	GetHeadquarterDetails(ctx context.Context, swiftCodePrefix string) (interface{}, error)
+	GetBranchDetails(ctx context.Context, swiftCode string) (interface{}, error)
+	LookupSwiftCodes(ctx context.Context, swiftCodes []string) ([]models.SwiftCodeLookupResult, error)
+	GetSwiftCodesByCountry(ctx context.Context, iso2 string, query models.CountryQuery) (interface{}, error)
+	SearchSwiftCodes(ctx context.Context, query string, iso2 string, limit int) ([]models.SwiftCodeSearchResult, error)
+	SuggestSwiftCodes(ctx context.Context, prefix string, limit int) ([]models.SwiftCodeSuggestion, error)
+	ExportSwiftCodes(ctx context.Context, iso2 string, fn func(models.SwiftCode) error) error
+	ValidateNewSwiftCode(ctx context.Context, newCode models.SwiftCodeBranch) (models.SwiftCode, error)
+	ValidateNewSwiftCodes(ctx context.Context, newCodes []models.SwiftCodeBranch) ([]models.SwiftCode, []error)
+	AddSwiftCode(ctx context.Context, newCode *models.SwiftCode) error
+	AddSwiftCodes(ctx context.Context, newCodes []*models.SwiftCode) error
+	UpdateSwiftCode(ctx context.Context, swiftCode string, patch models.SwiftCodePatch) error
+	DeleteSwiftCode(ctx context.Context, swiftCode string) error
+	DeleteSwiftCodeCascade(ctx context.Context, swiftCode string) ([]string, error)
+	RestoreSwiftCode(ctx context.Context, swiftCode string) error
+	PurgeDeletedSwiftCodes(ctx context.Context, retention time.Duration) (int, error)
+	GetSwiftCodeHistory(ctx context.Context, swiftCode string) (models.SwiftCodeHistory, error)
+	GetCountryName(ctx context.Context, iso2 string) (string, error)
 }
 
+// suggestionIndexTTL bounds how long writes made outside this service, such as
+// imports, can be missing from suggestions.
+const suggestionIndexTTL = 5 * time.Minute
+
 type SwiftCodeService struct {
 	repo repositories.ISwiftCodeRepository
+
+	indexMu      sync.Mutex
+	index        *suggest.Index
+	indexBuiltAt time.Time
 }
 
 func NewSwiftCodeService(repo repositories.ISwiftCodeRepository) ISwiftCodeService {
 	return &SwiftCodeService{repo: repo}
 }
 
-func (s *SwiftCodeService) GetHeadquarterDetails(swiftCodePrefix string) (interface{}, error) {
-	swiftCodes, err := s.repo.FindBySwiftCodePrefix(swiftCodePrefix)
+func (s *SwiftCodeService) GetHeadquarterDetails(ctx context.Context, swiftCodePrefix string) (interface{}, error) {
+	swiftCodes, err := s.repo.FindBySwiftCodePrefix(ctx, swiftCodePrefix)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(swiftCodes) == 0 {
-		return nil, nil
+	details := headquarterDetails(swiftCodes)
+	if details == nil {
+		return nil, fmt.Errorf("SWIFT code %s %w", swiftCodePrefix+validation.HeadquarterBranchCode, models.ErrNotFound)
 	}
 
+	return *details, nil
+}
+
+// headquarterDetails builds the details of the headquarter among codes, listing
+// every other code as its branch. It returns nil when there is no headquarter.
+func headquarterDetails(codes []models.SwiftCode) *models.SwiftCodeDetails {
 	var headquarter *models.SwiftCode
 	var branches []models.SwiftCodeBank
 
-	for _, code := range swiftCodes {
+	for _, code := range codes {
 		if code.IsHeadquarter() {
 			headquarter = &code
 		} else {
@@ -46,15 +79,16 @@ func (s *SwiftCodeService) GetHeadquarterDetails(swiftCodePrefix string) (interf
 				CountryISO2:   code.CountryISO2,
 				IsHeadquarter: false,
 				SwiftCode:     code.SwiftCode,
+				DeletedAt:     code.DeletionTime(),
 			})
 		}
 	}
 
 	if headquarter == nil {
-		return nil, nil
+		return nil
 	}
 
-	detailedResponse := models.SwiftCodeDetails{
+	return &models.SwiftCodeDetails{
 		Address:       headquarter.Address,
 		BankName:      headquarter.Name,
 		CountryISO2:   headquarter.CountryISO2,
@@ -62,44 +96,131 @@ func (s *SwiftCodeService) GetHeadquarterDetails(swiftCodePrefix string) (interf
 		IsHeadquarter: true,
 		SwiftCode:     headquarter.SwiftCode,
 		Branches:      branches,
+		DeletedAt:     headquarter.DeletionTime(),
 	}
-
-	return detailedResponse, nil
 }
 
-func (s *SwiftCodeService) GetBranchDetails(swiftCode string) (interface{}, error) {
-	branch, err := s.repo.FindBySwiftCode(swiftCode)
+func (s *SwiftCodeService) GetBranchDetails(ctx context.Context, swiftCode string) (interface{}, error) {
+	branch, err := s.repo.FindBySwiftCode(ctx, swiftCode)
 	if err != nil {
 		return nil, err
 	}
 
 	if branch.SwiftCode == "" {
-		return nil, nil
+		return nil, fmt.Errorf("SWIFT code %s %w", swiftCode, models.ErrNotFound)
 	}
 
-	response := models.SwiftCodeBranch{
+	return branchDetails(branch), nil
+}
+
+func branchDetails(branch models.SwiftCode) models.SwiftCodeBranch {
+	return models.SwiftCodeBranch{
 		Address:       branch.Address,
 		BankName:      branch.Name,
 		CountryISO2:   branch.CountryISO2,
 		CountryName:   branch.CountryName,
 		IsHeadquarter: false,
 		SwiftCode:     branch.SwiftCode,
+		DeletedAt:     branch.DeletionTime(),
 	}
+}
+
+// LookupSwiftCodes resolves many codes with a single repository query. Every
+// code gets a result in the order given, either its details or the reason it
+// could not be resolved.
+func (s *SwiftCodeService) LookupSwiftCodes(ctx context.Context, swiftCodes []string) ([]models.SwiftCodeLookupResult, error) {
+	results := make([]models.SwiftCodeLookupResult, len(swiftCodes))
+	var bankCodes []string
+	seen := make(map[string]bool)
 
-	return response, nil
+	for i, swiftCode := range swiftCodes {
+		results[i].SwiftCode = swiftCode
+		if _, err := validation.ParseBIC(swiftCode); err != nil {
+			results[i].Error = &models.LookupError{Code: models.LookupErrorInvalidFormat, Message: err.Error()}
+			continue
+		}
+		if bankCode := swiftCode[:8]; !seen[bankCode] {
+			seen[bankCode] = true
+			bankCodes = append(bankCodes, bankCode)
+		}
+	}
+
+	codes, err := s.repo.FindByBankCodes(ctx, bankCodes)
+	if err != nil {
+		return nil, err
+	}
+
+	byBankCode := make(map[string][]models.SwiftCode)
+	for _, code := range codes {
+		bankCode := code.SwiftCode[:8]
+		byBankCode[bankCode] = append(byBankCode[bankCode], code)
+	}
+
+	for i := range results {
+		if results[i].Error != nil {
+			continue
+		}
+		swiftCode := results[i].SwiftCode
+		bank := byBankCode[swiftCode[:8]]
+
+		if strings.HasSuffix(swiftCode, validation.HeadquarterBranchCode) {
+			if details := headquarterDetails(bank); details != nil {
+				results[i].Found = true
+				results[i].Details = *details
+			}
+		} else {
+			for _, code := range bank {
+				if code.SwiftCode == swiftCode {
+					results[i].Found = true
+					results[i].Details = branchDetails(code)
+					break
+				}
+			}
+		}
+
+		if !results[i].Found {
+			results[i].Error = &models.LookupError{Code: models.LookupErrorNotFound, Message: fmt.Sprintf("SWIFT code %s %v", swiftCode, models.ErrNotFound)}
+		}
+	}
+
+	return results, nil
 }
 
-func (s *SwiftCodeService) GetSwiftCodesByCountry(iso2 string) (interface{}, error) {
-	countryName, err := s.repo.FindCountryNameByISO2(iso2)
+// GetSwiftCodesByCountry returns one page of a country's codes. A page is full
+// when query.Limit codes are returned, in which case NextCursor points at the
+// page after it; a zero limit returns every matching code. A country without
+// any codes is not found.
+func (s *SwiftCodeService) GetSwiftCodesByCountry(ctx context.Context, iso2 string, query models.CountryQuery) (interface{}, error) {
+	countryName, err := s.repo.FindCountryNameByISO2(ctx, iso2)
 	if err != nil {
 		return nil, err
 	}
+	if countryName == "" {
+		return nil, fmt.Errorf("country %s %w", iso2, models.ErrNotFound)
+	}
 
-	swiftCodes, err := s.repo.FindByCountryISO2(iso2)
+	limit := query.Limit
+	if limit > 0 {
+		query.Limit = limit + 1
+	}
+
+	swiftCodes, err := s.repo.FindByCountryISO2(ctx, iso2, query)
 	if err != nil {
 		return nil, err
 	}
 
+	var nextCursor string
+	if limit > 0 && len(swiftCodes) > limit {
+		swiftCodes = swiftCodes[:limit]
+		last := swiftCodes[limit-1]
+		nextCursor = models.EncodeCursor(models.CountryCursor{
+			SortBy:     query.SortBy,
+			Descending: query.Descending,
+			Value:      last.SortValue(query.SortBy),
+			SwiftCode:  last.SwiftCode,
+		})
+	}
+
 	var SwiftCodeBranchs []models.SwiftCodeBank
 
 	for _, code := range swiftCodes {
@@ -109,6 +230,7 @@ func (s *SwiftCodeService) GetSwiftCodesByCountry(iso2 string) (interface{}, err
 			CountryISO2:   code.CountryISO2,
 			IsHeadquarter: code.IsHeadquarter(),
 			SwiftCode:     code.SwiftCode,
+			DeletedAt:     code.DeletionTime(),
 		})
 	}
 
@@ -116,19 +238,260 @@ func (s *SwiftCodeService) GetSwiftCodesByCountry(iso2 string) (interface{}, err
 		CountryISO2: iso2,
 		CountryName: countryName,
 		SwiftCodes:  SwiftCodeBranchs,
+		NextCursor:  nextCursor,
 	}, nil
 }
 
-func (s *SwiftCodeService) AddSwiftCode(newCode *models.SwiftCode) error {
+func (s *SwiftCodeService) SearchSwiftCodes(ctx context.Context, query string, iso2 string, limit int) ([]models.SwiftCodeSearchResult, error) {
+	scored, err := s.repo.Search(ctx, query, iso2, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]models.SwiftCodeSearchResult, 0, len(scored))
+	for _, result := range scored {
+		results = append(results, models.SwiftCodeSearchResult{
+			SwiftCodeBank: models.SwiftCodeBank{
+				Address:       result.Code.Address,
+				BankName:      result.Code.Name,
+				CountryISO2:   result.Code.CountryISO2,
+				IsHeadquarter: result.Code.IsHeadquarter(),
+				SwiftCode:     result.Code.SwiftCode,
+			},
+			Score: result.Score,
+		})
+	}
+
+	return results, nil
+}
+
+func (s *SwiftCodeService) SuggestSwiftCodes(ctx context.Context, prefix string, limit int) ([]models.SwiftCodeSuggestion, error) {
+	s.indexMu.Lock()
+	if s.index == nil || time.Since(s.indexBuiltAt) > suggestionIndexTTL {
+		codes, err := s.repo.FindAll(ctx)
+		if err != nil {
+			s.indexMu.Unlock()
+			return nil, err
+		}
+		s.index = suggest.NewIndex(codes)
+		s.indexBuiltAt = time.Now()
+	}
+	index := s.index
+	s.indexMu.Unlock()
+
+	return index.Suggest(prefix, limit), nil
+}
+
+// ExportSwiftCodes streams every code, or the codes of one country when iso2 is
+// given, to fn without loading them all into memory.
+func (s *SwiftCodeService) ExportSwiftCodes(ctx context.Context, iso2 string, fn func(models.SwiftCode) error) error {
+	return s.repo.Iterate(ctx, strings.ToUpper(iso2), fn)
+}
+
+// updateSuggestions applies a write to the suggestion index, if it has been
+// built yet.
+func (s *SwiftCodeService) updateSuggestions(apply func(index *suggest.Index)) {
+	s.indexMu.Lock()
+	defer s.indexMu.Unlock()
+	if s.index != nil {
+		apply(s.index)
+	}
+}
+
+// ValidateNewSwiftCode runs validation.ValidateNewSwiftCode against the country
+// name stored for the ISO2 code of newCode and returns the code to store.
+func (s *SwiftCodeService) ValidateNewSwiftCode(ctx context.Context, newCode models.SwiftCodeBranch) (models.SwiftCode, error) {
+	countryName := func(iso2 string) (string, error) { return s.repo.FindCountryNameByISO2(ctx, iso2) }
+	if err := validation.ValidateNewSwiftCode(newCode, countryName); err != nil {
+		return models.SwiftCode{}, err
+	}
+	return toSwiftCode(newCode), nil
+}
+
+// ValidateNewSwiftCodes validates each of newCodes like ValidateNewSwiftCode,
+// looking up the country name of each ISO2 code once. The code to store and the
+// error of each code are at its index.
+func (s *SwiftCodeService) ValidateNewSwiftCodes(ctx context.Context, newCodes []models.SwiftCodeBranch) ([]models.SwiftCode, []error) {
+	countryNames := make(map[string]string)
+	countryName := func(iso2 string) (string, error) {
+		if name, ok := countryNames[iso2]; ok {
+			return name, nil
+		}
+		name, err := s.repo.FindCountryNameByISO2(ctx, iso2)
+		if err == nil {
+			countryNames[iso2] = name
+		}
+		return name, err
+	}
+
+	validCodes := make([]models.SwiftCode, len(newCodes))
+	errs := make([]error, len(newCodes))
+	for i, newCode := range newCodes {
+		if errs[i] = validation.ValidateNewSwiftCode(newCode, countryName); errs[i] == nil {
+			validCodes[i] = toSwiftCode(newCode)
+		}
+	}
+	return validCodes, errs
+}
+
+func toSwiftCode(newCode models.SwiftCodeBranch) models.SwiftCode {
+	return models.SwiftCode{
+		Address:     newCode.Address,
+		Name:        newCode.BankName,
+		CountryISO2: newCode.CountryISO2,
+		SwiftCode:   newCode.SwiftCode,
+		CountryName: newCode.CountryName,
+	}
+}
+
+func (s *SwiftCodeService) AddSwiftCode(ctx context.Context, newCode *models.SwiftCode) error {
 	newCode.CountryISO2 = strings.ToUpper(newCode.CountryISO2)
 	newCode.CountryName = strings.ToUpper(newCode.CountryName)
-	return s.repo.Create(newCode)
+	if err := s.repo.Create(ctx, newCode); err != nil {
+		return err
+	}
+	s.updateSuggestions(func(index *suggest.Index) { index.Add(*newCode) })
+	return nil
+}
+
+// AddSwiftCodes inserts all of newCodes in one transaction, or none of them.
+func (s *SwiftCodeService) AddSwiftCodes(ctx context.Context, newCodes []*models.SwiftCode) error {
+	for _, newCode := range newCodes {
+		newCode.CountryISO2 = strings.ToUpper(newCode.CountryISO2)
+		newCode.CountryName = strings.ToUpper(newCode.CountryName)
+	}
+	if err := s.repo.CreateBatch(ctx, newCodes); err != nil {
+		return err
+	}
+	s.updateSuggestions(func(index *suggest.Index) {
+		for _, newCode := range newCodes {
+			index.Add(*newCode)
+		}
+	})
+	return nil
+}
+
+// UpdateSwiftCode applies the non-nil fields of patch to a stored code. The
+// SWIFT code and country of a record can't be changed.
+func (s *SwiftCodeService) UpdateSwiftCode(ctx context.Context, swiftCode string, patch models.SwiftCodePatch) error {
+	code, err := s.repo.FindBySwiftCode(ctx, swiftCode)
+	if err != nil {
+		return err
+	}
+	if code.SwiftCode == "" {
+		return fmt.Errorf("SWIFT code %s %w", swiftCode, models.ErrNotFound)
+	}
+
+	if patch.SwiftCode != nil && *patch.SwiftCode != code.SwiftCode {
+		return &validation.FieldError{Field: validation.FieldSwiftCode, Value: *patch.SwiftCode, Err: validation.ErrImmutable}
+	}
+	if patch.CountryISO2 != nil && !strings.EqualFold(*patch.CountryISO2, code.CountryISO2) {
+		return &validation.FieldError{Field: validation.FieldCountryISO2, Value: *patch.CountryISO2, Err: validation.ErrImmutable}
+	}
+
+	if patch.Address != nil {
+		code.Address = *patch.Address
+	}
+	if patch.BankName != nil {
+		code.Name = *patch.BankName
+	}
+	if patch.TownName != nil {
+		code.TownName = *patch.TownName
+	}
+	if patch.TimeZone != nil {
+		code.TimeZone = *patch.TimeZone
+	}
+
+	if code.Address == "" {
+		return &validation.FieldError{Field: validation.FieldAddress, Value: code.Address, Err: validation.ErrEmptyAddress}
+	}
+	if code.Name == "" {
+		return &validation.FieldError{Field: validation.FieldBankName, Value: code.Name, Err: validation.ErrEmptyBankName}
+	}
+
+	if err := s.repo.Update(ctx, &code); err != nil {
+		return err
+	}
+	s.updateSuggestions(func(index *suggest.Index) { index.Add(code) })
+	return nil
+}
+
+// DeleteSwiftCode deletes a code. A headquarter that still has branches is
+// refused with models.ErrConflict, so that the branches aren't left without it.
+func (s *SwiftCodeService) DeleteSwiftCode(ctx context.Context, swiftCode string) error {
+	_, err := s.deleteSwiftCode(ctx, swiftCode, false)
+	return err
+}
+
+// DeleteSwiftCodeCascade deletes a headquarter together with all of its
+// branches in one go and returns the deleted codes, sorted. A branch code is
+// deleted on its own.
+func (s *SwiftCodeService) DeleteSwiftCodeCascade(ctx context.Context, swiftCode string) ([]string, error) {
+	return s.deleteSwiftCode(ctx, swiftCode, true)
+}
+
+func (s *SwiftCodeService) deleteSwiftCode(ctx context.Context, swiftCode string, cascade bool) ([]string, error) {
+	removed := []string{swiftCode}
+	var err error
+	if strings.HasSuffix(swiftCode, validation.HeadquarterBranchCode) {
+		removed, err = s.repo.DeleteHeadquarter(ctx, swiftCode, cascade)
+	} else {
+		err = s.repo.Delete(ctx, swiftCode)
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.updateSuggestions(func(index *suggest.Index) {
+		for _, code := range removed {
+			index.Remove(code)
+		}
+	})
+	return removed, nil
 }
 
-func (s *SwiftCodeService) DeleteSwiftCode(swiftCode string) error {
-	return s.repo.Delete(swiftCode)
+// RestoreSwiftCode undoes the deletion of a code that hasn't been purged yet.
+func (s *SwiftCodeService) RestoreSwiftCode(ctx context.Context, swiftCode string) error {
+	if err := s.repo.Restore(ctx, swiftCode); err != nil {
+		return err
+	}
+	code, err := s.repo.FindBySwiftCode(ctx, swiftCode)
+	if err != nil {
+		return err
+	}
+	s.updateSuggestions(func(index *suggest.Index) { index.Add(code) })
+	return nil
+}
+
+// PurgeDeletedSwiftCodes removes the codes deleted more than retention ago for
+// good and returns how many there were.
+func (s *SwiftCodeService) PurgeDeletedSwiftCodes(ctx context.Context, retention time.Duration) (int, error) {
+	return s.repo.Purge(ctx, time.Now().Add(-retention))
+}
+
+// GetSwiftCodeHistory returns the audit entries of a code, oldest first. The
+// history of a deleted code can still be read; a code that was never written
+// is not found.
+func (s *SwiftCodeService) GetSwiftCodeHistory(ctx context.Context, swiftCode string) (models.SwiftCodeHistory, error) {
+	history := models.SwiftCodeHistory{SwiftCode: swiftCode, Entries: []models.AuditEntry{}}
+	entries, err := s.repo.FindHistory(ctx, swiftCode)
+	if err != nil {
+		return history, err
+	}
+	if len(entries) > 0 {
+		history.Entries = entries
+		return history, nil
+	}
+
+	code, err := s.repo.FindBySwiftCode(ctx, swiftCode)
+	if err != nil {
+		return history, err
+	}
+	if code.SwiftCode == "" {
+		return history, fmt.Errorf("SWIFT code %s %w", swiftCode, models.ErrNotFound)
+	}
+	return history, nil
 }
 
-func (s *SwiftCodeService) GetCountryName(iso2 string) (string, error) {
-	return s.repo.FindCountryNameByISO2(iso2)
+func (s *SwiftCodeService) GetCountryName(ctx context.Context, iso2 string) (string, error) {
+	return s.repo.FindCountryNameByISO2(ctx, iso2)
 }
@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"RemitlyTask/data"
+	"RemitlyTask/src/config"
+	"RemitlyTask/src/database"
+	"RemitlyTask/src/importer"
+	"RemitlyTask/src/migrations"
+	"RemitlyTask/src/repositories"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"gorm.io/gorm"
+)
+
+// Open returns the repository of the configured backend and a function that
+// releases it.
+func Open(ctx context.Context, cfg config.Config) (repositories.ISwiftCodeRepository, func() error, error) {
+	var repo repositories.ISwiftCodeRepository
+	closeRepo := func() error { return nil }
+
+	switch cfg.Storage.Driver {
+	case config.StorageMemory:
+		repo = repositories.NewMemorySwiftCodeRepository()
+	case config.StorageEmbedded:
+		memory := repositories.NewMemorySwiftCodeRepository()
+		if err := load(ctx, memory, "embedded directory", bytes.NewReader(data.DirectoryCSV)); err != nil {
+			return nil, nil, err
+		}
+		return repositories.NewReadOnlySwiftCodeRepository(memory), closeRepo, nil
+	case config.StorageSQLite, config.StoragePostgres:
+		db, dialect, err := Connect(cfg)
+		if err != nil {
+			return nil, nil, err
+		}
+		if cfg.Storage.AutoMigrate {
+			if err := database.Migrate(db, dialect); err != nil {
+				database.Close(db)
+				return nil, nil, err
+			}
+		}
+		if dialect == migrations.DialectSQLite {
+			repo = repositories.NewSQLiteSwiftCodeRepository(db)
+		} else {
+			repo = repositories.NewSwiftCodeRepository(db)
+		}
+		closeRepo = closeDB(db)
+	default:
+		return nil, nil, fmt.Errorf("unknown storage driver %q", cfg.Storage.Driver)
+	}
+
+	if cfg.Storage.SeedFile != "" {
+		if err := seed(ctx, repo, cfg.Storage.SeedFile); err != nil {
+			closeRepo()
+			return nil, nil, err
+		}
+	}
+	return repo, closeRepo, nil
+}
+
+// Connect opens the database of an SQL backend without migrating it and
+// returns the migration dialect it uses.
+func Connect(cfg config.Config) (*gorm.DB, string, error) {
+	switch cfg.Storage.Driver {
+	case config.StorageSQLite:
+		db, err := database.ConnectSQLite(cfg.Storage.SQLitePath)
+		return db, migrations.DialectSQLite, err
+	case config.StoragePostgres:
+		db, err := database.Connect(cfg.Database)
+		return db, migrations.DialectPostgres, err
+	}
+	return nil, "", fmt.Errorf("the %s storage has no database", cfg.Storage.Driver)
+}
+
+func closeDB(db *gorm.DB) func() error {
+	return func() error { return database.Close(db) }
+}
+
+// seed loads the directory file into an empty repository in one step.
+func seed(ctx context.Context, repo repositories.ISwiftCodeRepository, path string) error {
+	existing, err := repo.FindAll(ctx)
+	if err != nil {
+		return err
+	}
+	if len(existing) > 0 {
+		return nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("opening seed file: %w", err)
+	}
+	defer file.Close()
+
+	return load(ctx, repo, path, file)
+}
+
+func load(ctx context.Context, repo repositories.ISwiftCodeRepository, source string, r io.Reader) error {
+	report, err := importer.NewImporter(repo).Sync(ctx, r, false)
+	if err != nil {
+		return fmt.Errorf("loading %s: %w", source, err)
+	}
+	slog.Info("Loaded swift codes", "source", source, "added", len(report.Added), "rejected", len(report.Rejected))
+	return nil
+}
@@ -0,0 +1,186 @@
+package suggest
+
+import (
+	"RemitlyTask/src/models"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type entry struct {
+	key       string
+	swiftCode string
+}
+
+// Index answers prefix queries over SWIFT codes and bank names from memory.
+// Bank names are indexed from the start of every word, so both "UNI" and
+// "BANK" complete "UNICREDIT BANK".
+type Index struct {
+	mu      sync.RWMutex
+	byCode  []entry
+	byName  []entry
+	records map[string]models.SwiftCodeSuggestion
+}
+
+func NewIndex(codes []models.SwiftCode) *Index {
+	index := &Index{records: make(map[string]models.SwiftCodeSuggestion, len(codes))}
+	for _, code := range codes {
+		suggestion := toSuggestion(code)
+		index.records[code.SwiftCode] = suggestion
+		index.byCode = append(index.byCode, entry{key: code.SwiftCode, swiftCode: code.SwiftCode})
+		for _, key := range nameKeys(suggestion.BankName) {
+			index.byName = append(index.byName, entry{key: key, swiftCode: code.SwiftCode})
+		}
+	}
+	sort.Slice(index.byCode, func(i, j int) bool { return less(index.byCode[i], index.byCode[j]) })
+	sort.Slice(index.byName, func(i, j int) bool { return less(index.byName[i], index.byName[j]) })
+	return index
+}
+
+// Add inserts a code or replaces the stored version of it.
+func (i *Index) Add(code models.SwiftCode) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.remove(code.SwiftCode)
+	suggestion := toSuggestion(code)
+	i.records[code.SwiftCode] = suggestion
+	i.byCode = insert(i.byCode, entry{key: code.SwiftCode, swiftCode: code.SwiftCode})
+	for _, key := range nameKeys(suggestion.BankName) {
+		i.byName = insert(i.byName, entry{key: key, swiftCode: code.SwiftCode})
+	}
+}
+
+func (i *Index) Remove(swiftCode string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.remove(swiftCode)
+}
+
+func (i *Index) remove(swiftCode string) {
+	suggestion, ok := i.records[swiftCode]
+	if !ok {
+		return
+	}
+	delete(i.records, swiftCode)
+	i.byCode = del(i.byCode, entry{key: swiftCode, swiftCode: swiftCode})
+	for _, key := range nameKeys(suggestion.BankName) {
+		i.byName = del(i.byName, entry{key: key, swiftCode: swiftCode})
+	}
+}
+
+// Suggest returns up to limit completions of prefix. Matching SWIFT codes come
+// first, followed by one code per matching bank, preferring its headquarter.
+func (i *Index) Suggest(prefix string, limit int) []models.SwiftCodeSuggestion {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	normalized := normalize(prefix)
+	suggestions := []models.SwiftCodeSuggestion{}
+	if normalized == "" || limit <= 0 {
+		return suggestions
+	}
+
+	seen := make(map[string]bool)
+	if !strings.Contains(normalized, " ") {
+		for _, e := range matching(i.byCode, normalized) {
+			if len(suggestions) == limit {
+				return suggestions
+			}
+			seen[e.swiftCode] = true
+			suggestions = append(suggestions, i.records[e.swiftCode])
+		}
+	}
+
+	type bank struct{ name, iso2 string }
+	var banks []bank
+	best := make(map[bank]models.SwiftCodeSuggestion)
+	for _, e := range matching(i.byName, normalized) {
+		suggestion := i.records[e.swiftCode]
+		key := bank{suggestion.BankName, suggestion.CountryISO2}
+		current, ok := best[key]
+		if !ok {
+			banks = append(banks, key)
+		}
+		if !ok || (!isHeadquarter(current.SwiftCode) && isHeadquarter(suggestion.SwiftCode)) {
+			best[key] = suggestion
+		}
+	}
+
+	for _, key := range banks {
+		if len(suggestions) == limit {
+			break
+		}
+		suggestion := best[key]
+		if seen[suggestion.SwiftCode] {
+			continue
+		}
+		seen[suggestion.SwiftCode] = true
+		suggestions = append(suggestions, suggestion)
+	}
+
+	return suggestions
+}
+
+func matching(entries []entry, prefix string) []entry {
+	start := sort.Search(len(entries), func(i int) bool { return entries[i].key >= prefix })
+	end := start
+	for end < len(entries) && strings.HasPrefix(entries[end].key, prefix) {
+		end++
+	}
+	return entries[start:end]
+}
+
+func insert(entries []entry, e entry) []entry {
+	at := sort.Search(len(entries), func(i int) bool { return !less(entries[i], e) })
+	entries = append(entries, entry{})
+	copy(entries[at+1:], entries[at:])
+	entries[at] = e
+	return entries
+}
+
+func del(entries []entry, e entry) []entry {
+	at := sort.Search(len(entries), func(i int) bool { return !less(entries[i], e) })
+	if at < len(entries) && entries[at] == e {
+		entries = append(entries[:at], entries[at+1:]...)
+	}
+	return entries
+}
+
+func less(a, b entry) bool {
+	if a.key != b.key {
+		return a.key < b.key
+	}
+	return a.swiftCode < b.swiftCode
+}
+
+func nameKeys(name string) []string {
+	words := strings.Fields(normalize(name))
+	keys := make([]string, 0, len(words))
+	seen := make(map[string]bool, len(words))
+	for i := range words {
+		key := strings.Join(words[i:], " ")
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func normalize(s string) string {
+	return strings.Join(strings.Fields(strings.ToUpper(s)), " ")
+}
+
+func isHeadquarter(swiftCode string) bool {
+	return strings.HasSuffix(swiftCode, "XXX")
+}
+
+func toSuggestion(code models.SwiftCode) models.SwiftCodeSuggestion {
+	return models.SwiftCodeSuggestion{
+		SwiftCode:   code.SwiftCode,
+		BankName:    code.Name,
+		CountryISO2: code.CountryISO2,
+	}
+}
@@ -0,0 +1,76 @@
+package validation
+
+const HeadquarterBranchCode = "XXX"
+
+// BIC is a SWIFT code split into its ISO 9362 components.
+type BIC struct {
+	InstitutionCode string
+	CountryCode     string
+	LocationCode    string
+	BranchCode      string
+}
+
+func (b BIC) String() string {
+	return b.InstitutionCode + b.CountryCode + b.LocationCode + b.BranchCode
+}
+
+func (b BIC) IsHeadquarter() bool {
+	return b.BranchCode == HeadquarterBranchCode
+}
+
+func ParseBIC(code string) (BIC, error) {
+	if len(code) != 8 && len(code) != 11 {
+		return BIC{}, &FieldError{Field: FieldSwiftCode, Value: code, Err: ErrInvalidLength}
+	}
+
+	bic := BIC{
+		InstitutionCode: code[0:4],
+		CountryCode:     code[4:6],
+		LocationCode:    code[6:8],
+	}
+	if len(code) == 11 {
+		bic.BranchCode = code[8:11]
+	}
+
+	if !isUpperAlpha(bic.InstitutionCode) {
+		return BIC{}, &FieldError{Field: FieldInstitutionCode, Value: bic.InstitutionCode, Err: ErrInvalidInstitutionCode}
+	}
+	if !isUpperAlpha(bic.CountryCode) {
+		return BIC{}, &FieldError{Field: FieldCountryCode, Value: bic.CountryCode, Err: ErrInvalidCountryCode}
+	}
+	if !isUpperAlphanumeric(bic.LocationCode) {
+		return BIC{}, &FieldError{Field: FieldLocationCode, Value: bic.LocationCode, Err: ErrInvalidLocationCode}
+	}
+	if bic.BranchCode != "" && !isUpperAlphanumeric(bic.BranchCode) {
+		return BIC{}, &FieldError{Field: FieldBranchCode, Value: bic.BranchCode, Err: ErrInvalidBranchCode}
+	}
+
+	return bic, nil
+}
+
+// ValidateCountry checks that the country code embedded in the BIC is the
+// given ISO2 code.
+func (b BIC) ValidateCountry(iso2 string) error {
+	if b.CountryCode != iso2 {
+		return &FieldError{Field: FieldCountryISO2, Value: iso2, Err: ErrCountryMismatch}
+	}
+	return nil
+}
+
+func isUpperAlpha(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < 'A' || s[i] > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+func isUpperAlphanumeric(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if (s[i] < 'A' || s[i] > 'Z') && (s[i] < '0' || s[i] > '9') {
+			return false
+		}
+	}
+	return true
+}
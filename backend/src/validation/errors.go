@@ -0,0 +1,55 @@
+package validation
+
+import (
+	"RemitlyTask/src/models"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrInvalidLength          = errors.New("must be 8 or 11 characters long")
+	ErrInvalidInstitutionCode = errors.New("must consist of 4 uppercase letters")
+	ErrInvalidCountryCode     = errors.New("must consist of 2 uppercase letters")
+	ErrInvalidLocationCode    = errors.New("must consist of 2 uppercase letters or digits")
+	ErrInvalidBranchCode      = errors.New("must consist of 3 uppercase letters or digits")
+	ErrCountryMismatch        = errors.New("does not match the country code of the SWIFT code")
+	ErrInvalidISO2            = errors.New("must be 2 characters long")
+	ErrHeadquarterMismatch    = errors.New("does not match the branch code of the SWIFT code")
+	ErrEmptyAddress           = errors.New("can't be empty")
+	ErrEmptyBankName          = errors.New("can't be empty")
+	ErrImmutable              = errors.New("can't be changed")
+	ErrUnknownField           = errors.New("is not a field that can be updated")
+)
+
+const (
+	FieldSwiftCode       = "swiftCode"
+	FieldInstitutionCode = "institutionCode"
+	FieldCountryCode     = "countryCode"
+	FieldLocationCode    = "locationCode"
+	FieldBranchCode      = "branchCode"
+	FieldCountryISO2     = "countryISO2"
+	FieldIsHeadquarter   = "isHeadquarter"
+	FieldAddress         = "address"
+	FieldBankName        = "bankName"
+	FieldTownName        = "townName"
+	FieldTimeZone        = "timeZone"
+)
+
+type FieldError struct {
+	Field string
+	Value string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s %q %s", e.Field, e.Value, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Is makes every field error a models.ErrValidation.
+func (e *FieldError) Is(target error) bool {
+	return target == models.ErrValidation
+}
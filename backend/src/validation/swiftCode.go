@@ -0,0 +1,76 @@
+package validation
+
+import (
+	"RemitlyTask/src/models"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ValidateNewSwiftCode applies every rule a code has to pass before it is
+// added. countryName looks up the name stored for an upper-case ISO2 code,
+// empty when there is none, and is only called once the field rules pass.
+// Every field rule code breaks is joined into the error; another country name
+// than the stored one is a models.ErrConflict. The REST, bulk, gRPC and import
+// paths all check new codes with it.
+func ValidateNewSwiftCode(code models.SwiftCodeBranch, countryName func(iso2 string) (string, error)) error {
+	if errs := CheckSwiftCodeBranch(code); len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	stored, err := countryName(strings.ToUpper(code.CountryISO2))
+	if err != nil {
+		return err
+	}
+	if stored != "" && !strings.EqualFold(code.CountryName, stored) {
+		return fmt.Errorf("country name %s %w", code.CountryName, models.ErrConflict)
+	}
+	return nil
+}
+
+// CheckSwiftCodeBranch reports every rule code breaks that holds independently
+// of what is already stored.
+func CheckSwiftCodeBranch(code models.SwiftCodeBranch) []error {
+	var errs []error
+	if len(code.CountryISO2) != 2 {
+		errs = append(errs, &FieldError{Field: FieldCountryISO2, Value: code.CountryISO2, Err: ErrInvalidISO2})
+	}
+
+	bic, err := ParseBIC(code.SwiftCode)
+	if err != nil {
+		errs = append(errs, err)
+	} else {
+		if len(code.CountryISO2) == 2 {
+			if err := bic.ValidateCountry(strings.ToUpper(code.CountryISO2)); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if bic.IsHeadquarter() != code.IsHeadquarter {
+			errs = append(errs, &FieldError{Field: FieldIsHeadquarter, Value: strconv.FormatBool(code.IsHeadquarter), Err: ErrHeadquarterMismatch})
+		}
+	}
+
+	if code.Address == "" {
+		errs = append(errs, &FieldError{Field: FieldAddress, Value: code.Address, Err: ErrEmptyAddress})
+	}
+
+	return errs
+}
+
+// FieldErrors collects the field errors wrapped by err, including those of
+// errors joined with errors.Join.
+func FieldErrors(err error) []*FieldError {
+	switch e := err.(type) {
+	case *FieldError:
+		return []*FieldError{e}
+	case interface{ Unwrap() []error }:
+		var fieldErrs []*FieldError
+		for _, err := range e.Unwrap() {
+			fieldErrs = append(fieldErrs, FieldErrors(err)...)
+		}
+		return fieldErrs
+	case interface{ Unwrap() error }:
+		return FieldErrors(e.Unwrap())
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package integrationTests
 
 import (
 	"RemitlyTask/src/handlers"
+	"RemitlyTask/src/importer"
 	"RemitlyTask/src/models"
 	"RemitlyTask/tests/testHelpers"
 	"bytes"
@@ -30,6 +31,7 @@ func TestAddSwiftCodeIntegration(t *testing.T) {
 		input            models.SwiftCodeBranch
 		expectedStatus   int
 		expectedResponse map[string]string
+		expectedProblem  models.Problem
 	}{
 		{
 			name: "Valid SWIFT code",
@@ -39,11 +41,11 @@ func TestAddSwiftCodeIntegration(t *testing.T) {
 				CountryISO2:   "PL",
 				CountryName:   "POLAND",
 				IsHeadquarter: false,
-				SwiftCode:     "TESTTESTTES",
+				SwiftCode:     "TESTPLPWTES",
 			},
 			expectedStatus: http.StatusOK,
 			expectedResponse: map[string]string{
-				"message": "TESTTESTTES has been added to the database.",
+				"message": "TESTPLPWTES has been added to the database.",
 			},
 		},
 		{
@@ -57,8 +59,16 @@ func TestAddSwiftCodeIntegration(t *testing.T) {
 				SwiftCode:     "TESTTEE",
 			},
 			expectedStatus: http.StatusBadRequest,
-			expectedResponse: map[string]string{
-				"message": "Error inserting to database TESTTEE swift code must be 8 or 11 characters long.",
+			expectedProblem: models.Problem{
+				Type:     models.ProblemType(models.ErrorCodeValidation),
+				Title:    "Invalid request",
+				Status:   http.StatusBadRequest,
+				Detail:   "Error inserting to database swiftCode \"TESTTEE\" must be 8 or 11 characters long",
+				Instance: "/v1/swift-codes",
+				Code:     models.ErrorCodeValidation,
+				Errors: []models.FieldProblem{
+					{Field: "swiftCode", Value: "TESTTEE", Detail: "must be 8 or 11 characters long"},
+				},
 			},
 		},
 		{
@@ -69,11 +79,19 @@ func TestAddSwiftCodeIntegration(t *testing.T) {
 				CountryISO2:   "P",
 				CountryName:   "POLAND",
 				IsHeadquarter: false,
-				SwiftCode:     "TESTTESTTES",
+				SwiftCode:     "TESTPLPWTES",
 			},
 			expectedStatus: http.StatusBadRequest,
-			expectedResponse: map[string]string{
-				"message": "Error inserting to database this iso2 code: P does not exist.",
+			expectedProblem: models.Problem{
+				Type:     models.ProblemType(models.ErrorCodeValidation),
+				Title:    "Invalid request",
+				Status:   http.StatusBadRequest,
+				Detail:   "Error inserting to database countryISO2 \"P\" must be 2 characters long",
+				Instance: "/v1/swift-codes",
+				Code:     models.ErrorCodeValidation,
+				Errors: []models.FieldProblem{
+					{Field: "countryISO2", Value: "P", Detail: "must be 2 characters long"},
+				},
 			},
 		},
 	}
@@ -82,7 +100,7 @@ func TestAddSwiftCodeIntegration(t *testing.T) {
 			payload, err := json.Marshal(tc.input)
 			assert.NoError(t, err)
 
-			req, err := http.NewRequest("POST", ts.URL+"/v1/swift-codes/", bytes.NewBuffer(payload))
+			req, err := http.NewRequest("POST", ts.URL+"/v1/swift-codes", bytes.NewBuffer(payload))
 			assert.NoError(t, err)
 
 			req.Header.Set("Content-Type", "application/json")
@@ -93,10 +111,18 @@ func TestAddSwiftCodeIntegration(t *testing.T) {
 
 			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
 
-			var response map[string]string
-			err = json.NewDecoder(resp.Body).Decode(&response)
-			assert.NoError(t, err)
-			assert.Equal(t, tc.expectedResponse, response)
+			if tc.expectedStatus == http.StatusOK {
+				var response map[string]string
+				err = json.NewDecoder(resp.Body).Decode(&response)
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedResponse, response)
+			} else {
+				assert.Equal(t, models.ProblemContentType, resp.Header.Get("Content-Type"))
+				var problem models.Problem
+				err = json.NewDecoder(resp.Body).Decode(&problem)
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedProblem, problem)
+			}
 		})
 	}
 
@@ -243,6 +269,7 @@ func TestGetCodesByCountry(t *testing.T) {
 		input            string
 		expectedStatus   int
 		expectedResponse interface{}
+		expectedProblem  models.Problem
 	}{
 		{
 			name:           "Get SWIFT codes by valid ISO2 code",
@@ -273,8 +300,16 @@ func TestGetCodesByCountry(t *testing.T) {
 			name:           "Get SWIFT codes by invalid ISO2 code",
 			input:          "INVALID",
 			expectedStatus: http.StatusBadRequest,
-			expectedResponse: map[string]string{
-				"message": "Invalid ISO2 code length. It must be 2 characters long.",
+			expectedProblem: models.Problem{
+				Type:     models.ProblemType(models.ErrorCodeValidation),
+				Title:    "Invalid request",
+				Status:   http.StatusBadRequest,
+				Detail:   "Invalid ISO2 code length. It must be 2 characters long.",
+				Instance: "/v1/swift-codes/country/INVALID",
+				Code:     models.ErrorCodeValidation,
+				Errors: []models.FieldProblem{
+					{Field: "countryISO2", Value: "INVALID", Detail: "must be 2 characters long"},
+				},
 			},
 		},
 	}
@@ -296,10 +331,11 @@ func TestGetCodesByCountry(t *testing.T) {
 				assert.NoError(t, err)
 				assert.Equal(t, tc.expectedResponse, response)
 			} else {
-				var response map[string]string
-				err = json.NewDecoder(resp.Body).Decode(&response)
+				assert.Equal(t, models.ProblemContentType, resp.Header.Get("Content-Type"))
+				var problem models.Problem
+				err = json.NewDecoder(resp.Body).Decode(&problem)
 				assert.NoError(t, err)
-				assert.Equal(t, tc.expectedResponse, response)
+				assert.Equal(t, tc.expectedProblem, problem)
 			}
 		})
 	}
@@ -332,6 +368,7 @@ func TestDeleteCode(t *testing.T) {
 		input            string
 		expectedStatus   int
 		expectedResponse map[string]string
+		expectedProblem  models.Problem
 	}{
 		{
 			name:           "Delete existing SWIFT code",
@@ -345,8 +382,13 @@ func TestDeleteCode(t *testing.T) {
 			name:           "Delete non-existent SWIFT code",
 			input:          "NONEXISTENT",
 			expectedStatus: http.StatusNotFound,
-			expectedResponse: map[string]string{
-				"message": "Could not delete a record SWIFT code NONEXISTENT not found",
+			expectedProblem: models.Problem{
+				Type:     models.ProblemType(models.ErrorCodeNotFound),
+				Title:    "Resource not found",
+				Status:   http.StatusNotFound,
+				Detail:   "Could not delete a record SWIFT code NONEXISTENT not found",
+				Instance: "/v1/swift-codes/NONEXISTENT",
+				Code:     models.ErrorCodeNotFound,
 			},
 		},
 	}
@@ -362,10 +404,117 @@ func TestDeleteCode(t *testing.T) {
 
 			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
 
-			var response map[string]string
+			if tc.expectedStatus == http.StatusOK {
+				var response map[string]string
+				err = json.NewDecoder(resp.Body).Decode(&response)
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedResponse, response)
+			} else {
+				assert.Equal(t, models.ProblemContentType, resp.Header.Get("Content-Type"))
+				var problem models.Problem
+				err = json.NewDecoder(resp.Body).Decode(&problem)
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedProblem, problem)
+			}
+		})
+	}
+}
+
+func TestSearchCodesIntegration(t *testing.T) {
+	db := testHelpers.SetupTestDB(t)
+	defer testHelpers.CleanupTestDB(t, db)
+
+	handler := handlers.NewSwiftCodeHandler(db)
+	require.NotNil(t, handler)
+
+	ts := testHelpers.SetupTestServer(handler)
+	require.NotNil(t, ts)
+	defer ts.Close()
+
+	testData := []models.SwiftCode{
+		{
+			Address:     "PIAZZA GAE AULENTI 3 MILANO",
+			Name:        "UNICREDIT S.P.A.",
+			CountryISO2: "IT",
+			CountryName: "ITALY",
+			SwiftCode:   "UNCRITMMXXX",
+			TownName:    "MILANO",
+		},
+		{
+			Address:     "LOPUSZANSKA 38 D WARSZAWA",
+			Name:        "ALIOR BANK SPOLKA AKCYJNA",
+			CountryISO2: "PL",
+			CountryName: "POLAND",
+			SwiftCode:   "ALBPPLPWXXX",
+			TownName:    "WARSZAWA",
+		},
+	}
+
+	for _, data := range testData {
+		err := db.Create(&data).Error
+		assert.NoError(t, err)
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		expectedCodes []string
+	}{
+		{name: "Search by bank name", query: "q=alior", expectedCodes: []string{"ALBPPLPWXXX"}},
+		{name: "Search by bank name and town", query: "q=UniCredit+Milan", expectedCodes: []string{"UNCRITMMXXX"}},
+		{name: "Search with country filter", query: "q=alior&country=IT", expectedCodes: []string{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := http.Get(ts.URL + "/v1/swift-codes/search?" + tc.query)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+			var response models.SwiftCodeSearch
 			err = json.NewDecoder(resp.Body).Decode(&response)
 			assert.NoError(t, err)
-			assert.Equal(t, tc.expectedResponse, response)
+
+			codes := []string{}
+			for _, result := range response.Results {
+				codes = append(codes, result.SwiftCode)
+			}
+			assert.Equal(t, tc.expectedCodes, codes)
 		})
 	}
 }
+
+func TestExportCodesIntegration(t *testing.T) {
+	db := testHelpers.SetupTestDB(t)
+	defer testHelpers.CleanupTestDB(t, db)
+
+	handler := handlers.NewSwiftCodeHandler(db)
+	require.NotNil(t, handler)
+
+	ts := testHelpers.SetupTestServer(handler)
+	require.NotNil(t, ts)
+	defer ts.Close()
+
+	testData := []models.SwiftCode{
+		{Address: "LOPUSZANSKA 38 D WARSZAWA", Name: "ALIOR BANK SPOLKA AKCYJNA", CountryISO2: "PL", CountryName: "POLAND", SwiftCode: "ALBPPLPWXXX", CodeType: "BIC11", TownName: "WARSZAWA", TimeZone: "Europe/Warsaw"},
+		{Address: "PIAZZA GAE AULENTI 3 MILANO", Name: "UNICREDIT S.P.A.", CountryISO2: "IT", CountryName: "ITALY", SwiftCode: "UNCRITMMXXX", CodeType: "BIC11", TownName: "MILANO", TimeZone: "Europe/Rome"},
+	}
+	for _, data := range testData {
+		err := db.Create(&data).Error
+		assert.NoError(t, err)
+	}
+
+	resp, err := http.Get(ts.URL + "/v1/swift-codes/export?format=csv&country=PL")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	rows, rejected, err := importer.ReadRows(resp.Body)
+	assert.NoError(t, err)
+	assert.Empty(t, rejected)
+	require.Len(t, rows, 1)
+	assert.Equal(t, "ALBPPLPWXXX", rows[0].Code.SwiftCode)
+	assert.Equal(t, "Europe/Warsaw", rows[0].Code.TimeZone)
+}
@@ -1,41 +1,36 @@
 package testHelpers
 
 import (
-	"RemitlyTask/src/models"
-	"log"
-	"os"
+	"RemitlyTask/src/config"
+	"RemitlyTask/src/database"
+	"RemitlyTask/src/migrations"
 	"testing"
 
-	"github.com/joho/godotenv"
-	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
 func SetupTestDB(t *testing.T) *gorm.DB {
-	err := godotenv.Load("../../db.env")
+	cfg, err := config.Load(config.Sources{EnvFile: "../../db.env"})
 	if err != nil {
-		log.Fatal("Error loading .env file")
+		t.Fatalf("Invalid test database configuration: %v", err)
 	}
-	host := os.Getenv("DB_HOST")
-	user := os.Getenv("POSTGRES_USER")
-	password := os.Getenv("POSTGRES_PASSWORD")
-	databaseName := os.Getenv("POSTGRES_DB")
-	port := os.Getenv("DB_PORT")
-
-	dsn := "host=" + host + " user=" + user + " password=" + password + " dbname=" + databaseName + " port=" + port
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
 
+	db, err := database.Open(cfg.Database)
 	if err != nil {
 		t.Fatalf("Failed to connect to test database: %v", err)
 	}
-
-	db.AutoMigrate(&models.SwiftCode{})
 	return db
 }
 
+// CleanupTestDB reverts every migration, so the next test starts from the
+// schema the migrations create.
 func CleanupTestDB(t *testing.T, db *gorm.DB) {
-	err := db.Migrator().DropTable(&models.SwiftCode{})
+	migrator, err := migrations.NewMigrator(db, migrations.DialectPostgres)
+	if err == nil {
+		_, err = migrator.Down(-1)
+	}
 	if err != nil {
 		t.Fatalf("Failed to clean up test database: %v", err)
 	}
+	database.Close(db)
 }
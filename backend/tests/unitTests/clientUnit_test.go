@@ -0,0 +1,246 @@
+package unitTests
+
+import (
+	"RemitlyTask/src/client"
+	"RemitlyTask/src/handlers"
+	"RemitlyTask/src/models"
+	"RemitlyTask/src/repositories"
+	"RemitlyTask/src/router"
+	"RemitlyTask/src/services"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T, url string) *client.Client {
+	c, err := client.New(url, client.Options{Backoff: time.Millisecond})
+	require.NoError(t, err)
+	return c
+}
+
+func TestClient(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+	repo := repositories.NewMemorySwiftCodeRepository()
+	require.NoError(t, repo.CreateBatch(ctx, []*models.SwiftCode{
+		{SwiftCode: "AAAAPLPWXXX", Name: "ALPHA BANK", Address: "PROSTA 1", CountryISO2: "PL", CountryName: "POLAND", TownName: "WARSZAWA"},
+		{SwiftCode: "AAAAPLPWKRK", Name: "ALPHA BANK", Address: "RYNEK 2", CountryISO2: "PL", CountryName: "POLAND", TownName: "KRAKOW"},
+	}))
+	handler := handlers.NewSwiftCodeHandlerByService(services.NewSwiftCodeService(repo))
+	ts := httptest.NewServer(router.New(handler, router.Options{}))
+	defer ts.Close()
+	c := newTestClient(t, ts.URL)
+
+	t.Run("TestClient_getCode", func(t *testing.T) {
+		details, err := c.GetCode(ctx, "AAAAPLPWXXX")
+		require.NoError(t, err)
+		assert.True(t, details.IsHeadquarter)
+		assert.Equal(t, "POLAND", details.CountryName)
+		require.Len(t, details.Branches, 1)
+		assert.Equal(t, "AAAAPLPWKRK", details.Branches[0].SwiftCode)
+	})
+
+	t.Run("TestClient_getCountry", func(t *testing.T) {
+		page, err := c.GetCountry(ctx, "pl", client.CountryOptions{Limit: 1})
+		require.NoError(t, err)
+		assert.Equal(t, "PL", page.CountryISO2)
+		require.Len(t, page.SwiftCodes, 1)
+		assert.NotEmpty(t, page.NextCursor)
+
+		page, err = c.GetCountry(ctx, "PL", client.CountryOptions{Limit: 1, Cursor: page.NextCursor})
+		require.NoError(t, err)
+		require.Len(t, page.SwiftCodes, 1)
+		assert.Equal(t, "AAAAPLPWXXX", page.SwiftCodes[0].SwiftCode)
+	})
+
+	t.Run("TestClient_search", func(t *testing.T) {
+		search, err := c.Search(ctx, "rynek", "PL", 5)
+		require.NoError(t, err)
+		require.NotEmpty(t, search.Results)
+		assert.Equal(t, "AAAAPLPWKRK", search.Results[0].SwiftCode)
+	})
+
+	t.Run("TestClient_lookup", func(t *testing.T) {
+		results, err := c.Lookup(ctx, []string{"AAAAPLPWKRK", "BBBBPLPWXXX"})
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.True(t, results[0].Found)
+		assert.Equal(t, "RYNEK 2", results[0].Details.(models.SwiftCodeDetails).Address)
+		assert.False(t, results[1].Found)
+		assert.Equal(t, models.LookupErrorNotFound, results[1].Error.Code)
+	})
+
+	t.Run("TestClient_addAndDelete", func(t *testing.T) {
+		newCode := models.SwiftCodeBranch{Address: "NOWA 4", BankName: "GAMMA BANK", CountryISO2: "PL", CountryName: "POLAND", IsHeadquarter: true, SwiftCode: "CCCCPLPWXXX"}
+		require.NoError(t, c.Add(ctx, newCode))
+
+		err := c.Add(ctx, newCode)
+		assert.ErrorIs(t, err, models.ErrAlreadyExists)
+
+		require.NoError(t, c.Delete(ctx, "CCCCPLPWXXX"))
+		_, err = c.GetCode(ctx, "CCCCPLPWXXX")
+		assert.ErrorIs(t, err, models.ErrNotFound)
+		assert.ErrorIs(t, c.Delete(ctx, "CCCCPLPWXXX"), models.ErrNotFound)
+	})
+
+	t.Run("TestClient_deleteCascade", func(t *testing.T) {
+		require.NoError(t, c.Add(ctx, models.SwiftCodeBranch{Address: "NOWA 5", BankName: "DELTA BANK", CountryISO2: "PL", CountryName: "POLAND", IsHeadquarter: true, SwiftCode: "DDDDPLPWXXX"}))
+		require.NoError(t, c.Add(ctx, models.SwiftCodeBranch{Address: "NOWA 6", BankName: "DELTA BANK", CountryISO2: "PL", CountryName: "POLAND", SwiftCode: "DDDDPLPWKRK"}))
+
+		assert.ErrorIs(t, c.Delete(ctx, "DDDDPLPWXXX"), models.ErrConflict)
+		removed, err := c.DeleteCascade(ctx, "DDDDPLPWXXX")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"DDDDPLPWKRK", "DDDDPLPWXXX"}, removed)
+	})
+
+	t.Run("TestClient_apiError", func(t *testing.T) {
+		_, err := c.GetCountry(ctx, "POL", client.CountryOptions{})
+		var apiErr *client.APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+		assert.Equal(t, handlers.ErrInvalidISO2Length, apiErr.Message)
+		assert.Equal(t, "countryISO2", apiErr.Problem.Errors[0].Field)
+		assert.ErrorIs(t, err, models.ErrValidation)
+	})
+}
+
+func TestClientRetries(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("TestClientRetries_recovers", func(t *testing.T) {
+		var calls atomic.Int32
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Write([]byte(`{"message":"CCCCPLPWXXX was removed."}`))
+		}))
+		defer ts.Close()
+
+		require.NoError(t, newTestClient(t, ts.URL).Delete(ctx, "CCCCPLPWXXX"))
+		assert.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("TestClientRetries_givesUp", func(t *testing.T) {
+		var calls atomic.Int32
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.Header().Set("Content-Type", models.ProblemContentType)
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"type":"urn:swift-codes:problem:internal_error","title":"Internal server error","status":500,"detail":"Could not delete a record CCCCPLPWXXX","code":"internal_error"}`))
+		}))
+		defer ts.Close()
+
+		err := newTestClient(t, ts.URL).Delete(ctx, "CCCCPLPWXXX")
+		var apiErr *client.APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusInternalServerError, apiErr.StatusCode)
+		assert.Equal(t, "Could not delete a record CCCCPLPWXXX", apiErr.Message)
+		assert.Equal(t, int32(client.DefaultMaxRetries+1), calls.Load())
+	})
+
+	t.Run("TestClientRetries_noRetryOnClientError", func(t *testing.T) {
+		var calls atomic.Int32
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer ts.Close()
+
+		_, err := newTestClient(t, ts.URL).GetCode(ctx, "CCCCPLPWXXX")
+		var apiErr *client.APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusText(http.StatusNotFound), apiErr.Message)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("TestClientRetries_addIsNotRetriedAfterSending", func(t *testing.T) {
+		var calls atomic.Int32
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusGatewayTimeout)
+		}))
+		defer ts.Close()
+
+		err := newTestClient(t, ts.URL).Add(ctx, models.SwiftCodeBranch{SwiftCode: "CCCCPLPWXXX"})
+		var apiErr *client.APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusGatewayTimeout, apiErr.StatusCode)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("TestClientRetries_addIsRetriedWhenUnsent", func(t *testing.T) {
+		var dials atomic.Int32
+		transport := &http.Transport{DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			dials.Add(1)
+			return nil, &net.OpError{Op: "dial", Net: network, Err: errors.New("connection refused")}
+		}}
+		c, err := client.New("http://swift-codes.test", client.Options{HTTPClient: &http.Client{Transport: transport}, Backoff: time.Millisecond})
+		require.NoError(t, err)
+
+		err = c.Add(ctx, models.SwiftCodeBranch{SwiftCode: "CCCCPLPWXXX"})
+		assert.Error(t, err)
+		assert.Equal(t, int32(client.DefaultMaxRetries+1), dials.Load())
+	})
+
+	t.Run("TestClientRetries_deleteAppliedBeforeFailure", func(t *testing.T) {
+		var calls atomic.Int32
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", models.ProblemContentType)
+			if calls.Add(1) == 1 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"type":"urn:swift-codes:problem:not_found","title":"Resource not found","status":404,"detail":"Could not delete a record SWIFT code CCCCPLPWXXX not found","code":"not_found"}`))
+		}))
+		defer ts.Close()
+
+		require.NoError(t, newTestClient(t, ts.URL).Delete(ctx, "CCCCPLPWXXX"))
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("TestClientRetries_lookupIsRetried", func(t *testing.T) {
+		var calls atomic.Int32
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) < 2 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Write([]byte(`{"results":[]}`))
+		}))
+		defer ts.Close()
+
+		_, err := newTestClient(t, ts.URL).Lookup(ctx, []string{"CCCCPLPWXXX"})
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("TestClientRetries_cancelled", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer ts.Close()
+
+		c, err := client.New(ts.URL, client.Options{Backoff: time.Hour, MaxBackoff: time.Hour})
+		require.NoError(t, err)
+		ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		err = c.Delete(ctx, "CCCCPLPWXXX")
+		var apiErr *client.APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusBadGateway, apiErr.StatusCode)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+}
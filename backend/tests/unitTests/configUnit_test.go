@@ -0,0 +1,157 @@
+package unitTests
+
+import (
+	"RemitlyTask/src/config"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Run("TestLoadConfig_precedence", func(t *testing.T) {
+		file := writeConfigFile(t, "config.yaml", `
+server:
+  addr: ":9000"
+  readTimeout: 3s
+database:
+  host: filehost
+  user: fileuser
+  name: filedb
+  maxOpenConns: 20
+logLevel: warn
+`)
+		envFile := writeConfigFile(t, "db.env", "POSTGRES_USER=envfileuser\nPOSTGRES_DB=envfiledb\nDB_PORT=5433 \n")
+		t.Setenv("DB_HOST", "envhost")
+		t.Setenv("POSTGRES_DB", "envdb")
+
+		cfg, err := config.Load(config.Sources{
+			File:    file,
+			EnvFile: envFile,
+			Flags:   map[string]string{"addr": ":9100", "db-max-idle-conns": "7"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, ":9100", cfg.Server.Addr)
+		assert.Equal(t, config.Duration(3*time.Second), cfg.Server.ReadTimeout)
+		assert.Equal(t, config.Default().Server.WriteTimeout, cfg.Server.WriteTimeout)
+		assert.Equal(t, "envhost", cfg.Database.Host)
+		assert.Equal(t, 5433, cfg.Database.Port)
+		assert.Equal(t, "envfileuser", cfg.Database.User)
+		assert.Equal(t, "envdb", cfg.Database.Name)
+		assert.Equal(t, 20, cfg.Database.MaxOpenConns)
+		assert.Equal(t, 7, cfg.Database.MaxIdleConns)
+		assert.Equal(t, "warn", cfg.LogLevel)
+	})
+
+	t.Run("TestLoadConfig_toml", func(t *testing.T) {
+		file := writeConfigFile(t, "config.toml", `
+logLevel = "debug"
+
+[database]
+host = "tomlhost"
+user = "postgres"
+name = "swift_codes"
+connMaxLifetime = "1h"
+`)
+
+		cfg, err := config.Load(config.Sources{File: file})
+
+		require.NoError(t, err)
+		assert.Equal(t, "tomlhost", cfg.Database.Host)
+		assert.Equal(t, config.Duration(time.Hour), cfg.Database.ConnMaxLifetime)
+		assert.Equal(t, "debug", cfg.LogLevel)
+	})
+
+	t.Run("TestLoadConfig_missingEnvFileIsOptional", func(t *testing.T) {
+		cfg, err := config.Load(config.Sources{
+			EnvFile: filepath.Join(t.TempDir(), "missing.env"),
+			Flags:   map[string]string{"db-user": "postgres", "db-name": "swift_codes"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "swift_codes", cfg.Database.Name)
+	})
+
+	t.Run("TestLoadConfig_invalid", func(t *testing.T) {
+		valid := map[string]string{"db-user": "postgres", "db-name": "swift_codes"}
+		testCases := []struct {
+			name  string
+			flags map[string]string
+		}{
+			{name: "Missing database name", flags: map[string]string{"db-name": ""}},
+			{name: "Bad port", flags: map[string]string{"db-port": "postgres"}},
+			{name: "Port out of range", flags: map[string]string{"db-port": "70000"}},
+			{name: "Bad duration", flags: map[string]string{"read-timeout": "10"}},
+			{name: "Bad address", flags: map[string]string{"addr": "8080"}},
+			{name: "Bad gRPC address", flags: map[string]string{"grpc-addr": "9090"}},
+			{name: "Bad log level", flags: map[string]string{"log-level": "verbose"}},
+			{name: "Bad trusted proxy", flags: map[string]string{"trusted-proxies": "10.0.0.1,proxy.local"}},
+			{name: "Unknown storage driver", flags: map[string]string{"storage": "mysql"}},
+			{name: "Negative deleted retention", flags: map[string]string{"deleted-retention": "-1h"}},
+			{name: "More idle than open connections", flags: map[string]string{"db-max-open-conns": "2", "db-max-idle-conns": "3"}},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				flags := make(map[string]string)
+				for key, value := range valid {
+					flags[key] = value
+				}
+				for key, value := range tc.flags {
+					flags[key] = value
+				}
+
+				_, err := config.Load(config.Sources{Flags: flags})
+				assert.Error(t, err)
+			})
+		}
+	})
+
+	t.Run("TestLoadConfig_trustedProxies", func(t *testing.T) {
+		t.Setenv("TRUSTED_PROXIES", "10.0.0.1, 192.168.0.0/16")
+		cfg, err := config.Load(config.Sources{Flags: map[string]string{"storage": config.StorageMemory}})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"10.0.0.1", "192.168.0.0/16"}, cfg.Server.TrustedProxies)
+		assert.Empty(t, config.Default().Server.TrustedProxies)
+	})
+
+	t.Run("TestLoadConfig_storageWithoutDatabase", func(t *testing.T) {
+		t.Setenv("POSTGRES_USER", "")
+		cfg, err := config.Load(config.Sources{Flags: map[string]string{"storage": config.StorageMemory}})
+
+		require.NoError(t, err)
+		assert.Equal(t, config.StorageMemory, cfg.Storage.Driver)
+	})
+
+	t.Run("TestLoadConfig_unknownFileField", func(t *testing.T) {
+		file := writeConfigFile(t, "config.yaml", "database:\n  hots: typo\n")
+
+		_, err := config.Load(config.Sources{File: file})
+		assert.Error(t, err)
+	})
+}
+
+func TestConfigFlags(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags := config.BindFlags(fs)
+	err := fs.Parse([]string{"-env-file", "", "-db-host", "localhost", "-db-port", "5432", "-db-password", "", "-db-user", "postgres", "-db-name", "swift_codes", "-db-connect-timeout", "1500ms"})
+	require.NoError(t, err)
+
+	cfg, err := flags.Load()
+
+	require.NoError(t, err)
+	assert.Equal(t, "postgres", cfg.Database.User)
+	assert.Equal(t, "host=localhost port=5432 user=postgres password='' dbname=swift_codes sslmode=disable connect_timeout=2", cfg.Database.DSN())
+}
@@ -0,0 +1,231 @@
+package unitTests
+
+import (
+	"RemitlyTask/src/graph"
+	"RemitlyTask/src/models"
+	"RemitlyTask/src/repositories"
+	"RemitlyTask/src/services"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingRepository counts the queries a GraphQL query sends to the
+// repository it wraps.
+type countingRepository struct {
+	repositories.ISwiftCodeRepository
+	bankCodeQueries atomic.Int32
+	countryQueries  atomic.Int32
+}
+
+func (r *countingRepository) FindByBankCodes(ctx context.Context, bankCodes []string) ([]models.SwiftCode, error) {
+	r.bankCodeQueries.Add(1)
+	return r.ISwiftCodeRepository.FindByBankCodes(ctx, bankCodes)
+}
+
+func (r *countingRepository) FindByCountryISO2(ctx context.Context, iso2 string, query models.CountryQuery) ([]models.SwiftCode, error) {
+	r.countryQueries.Add(1)
+	return r.ISwiftCodeRepository.FindByCountryISO2(ctx, iso2, query)
+}
+
+type graphError struct {
+	Message    string         `json:"message"`
+	Path       []any          `json:"path"`
+	Extensions map[string]any `json:"extensions"`
+}
+
+func execGraph(t *testing.T, schema *graph.Schema, query string, data any) []graphError {
+	response := schema.Exec(context.Background(), query, "", nil)
+	var errs []graphError
+	encoded, err := json.Marshal(response.Errors)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(encoded, &errs))
+	if data != nil && response.Data != nil {
+		require.NoError(t, json.Unmarshal(response.Data, data))
+	}
+	return errs
+}
+
+// graphBank is the institution code of the i-th bank of TestGraph.
+func graphBank(i int) string {
+	return string([]byte{'B', 'K', byte('A' + i/26), byte('A' + i%26)})
+}
+
+func TestGraph(t *testing.T) {
+	ctx := context.Background()
+	memory := repositories.NewMemorySwiftCodeRepository()
+	seed := []*models.SwiftCode{
+		{SwiftCode: "BBBBDEFFXXX", Name: "BETA BANK", Address: "MAIN 3", CountryISO2: "DE", CountryName: "GERMANY", TownName: "FRANKFURT"},
+	}
+	for i := 0; i < 30; i++ {
+		seed = append(seed,
+			&models.SwiftCode{SwiftCode: graphBank(i) + "PLPWXXX", Name: fmt.Sprintf("BANK %02d", i), Address: "PROSTA 1", CountryISO2: "PL", CountryName: "POLAND"},
+			&models.SwiftCode{SwiftCode: graphBank(i) + "PLPWKRK", Name: fmt.Sprintf("BANK %02d", i), Address: "RYNEK 2", CountryISO2: "PL", CountryName: "POLAND"},
+		)
+	}
+	seed = append(seed, &models.SwiftCode{SwiftCode: "ZZZZPLPWKRK", Name: "ORPHAN BANK", Address: "BOCZNA 5", CountryISO2: "PL", CountryName: "POLAND"})
+	require.NoError(t, memory.CreateBatch(ctx, seed))
+
+	repo := &countingRepository{ISwiftCodeRepository: memory}
+	schema := graph.NewSchema(services.NewSwiftCodeService(repo))
+
+	t.Run("TestGraph_headquarter", func(t *testing.T) {
+		var data struct {
+			Headquarter struct {
+				BankName string
+				Branches []struct {
+					SwiftCode   string
+					CountryName string
+					Headquarter struct{ SwiftCode string }
+				}
+				Country struct{ ISO2, Name string }
+			}
+		}
+		errs := execGraph(t, schema, `{ headquarter(code: "BKABPLPWXXX") {
+			bankName
+			branches { swiftCode countryName headquarter { swiftCode } }
+			country { iso2 name }
+		} }`, &data)
+		require.Empty(t, errs)
+		assert.Equal(t, "BANK 01", data.Headquarter.BankName)
+		require.Len(t, data.Headquarter.Branches, 1)
+		assert.Equal(t, "BKABPLPWKRK", data.Headquarter.Branches[0].SwiftCode)
+		assert.Equal(t, "POLAND", data.Headquarter.Branches[0].CountryName)
+		assert.Equal(t, "BKABPLPWXXX", data.Headquarter.Branches[0].Headquarter.SwiftCode)
+		assert.Equal(t, "PL", data.Headquarter.Country.ISO2)
+	})
+
+	t.Run("TestGraph_branchHeadquarter", func(t *testing.T) {
+		var data struct {
+			Branch, Orphan struct {
+				IsHeadquarter bool
+				Headquarter   *struct{ Address string }
+			}
+		}
+		errs := execGraph(t, schema, `{
+			branch: swiftCode(code: "BKACPLPWKRK") { isHeadquarter headquarter { address } }
+			orphan: swiftCode(code: "ZZZZPLPWKRK") { isHeadquarter headquarter { address } }
+		}`, &data)
+		require.Empty(t, errs)
+		assert.False(t, data.Branch.IsHeadquarter)
+		require.NotNil(t, data.Branch.Headquarter)
+		assert.Equal(t, "PROSTA 1", data.Branch.Headquarter.Address)
+		assert.Nil(t, data.Orphan.Headquarter)
+	})
+
+	t.Run("TestGraph_batchesHeadquarters", func(t *testing.T) {
+		repo.bankCodeQueries.Store(0)
+		repo.countryQueries.Store(0)
+		var data struct {
+			Country struct {
+				SwiftCodes struct {
+					Nodes []struct {
+						SwiftCode   string
+						Headquarter *struct{ Branches []struct{ SwiftCode string } }
+						Country     struct {
+							SwiftCodes struct{ Nodes []struct{ SwiftCode string } }
+						}
+					}
+				}
+			}
+		}
+		errs := execGraph(t, schema, `{ country(iso2: "pl") { swiftCodes(first: 61) { nodes {
+			swiftCode
+			headquarter { branches { swiftCode } }
+			country { swiftCodes(first: 2) { nodes { swiftCode } } }
+		} } } }`, &data)
+		require.Empty(t, errs)
+
+		nodes := data.Country.SwiftCodes.Nodes
+		require.Len(t, nodes, 61)
+		for _, node := range nodes[:60] {
+			require.NotNil(t, node.Headquarter, node.SwiftCode)
+			assert.Len(t, node.Headquarter.Branches, 1)
+			assert.Len(t, node.Country.SwiftCodes.Nodes, 2)
+		}
+		assert.Nil(t, nodes[60].Headquarter)
+		assert.Equal(t, int32(1), repo.bankCodeQueries.Load())
+		assert.Equal(t, int32(2), repo.countryQueries.Load())
+	})
+
+	t.Run("TestGraph_pagesPerCountry", func(t *testing.T) {
+		repo.countryQueries.Store(0)
+		var data struct {
+			PL, DE, Again struct {
+				SwiftCodes struct{ Nodes []struct{ SwiftCode string } }
+			}
+		}
+		errs := execGraph(t, schema, `{
+			pl: country(iso2: "PL") { swiftCodes(first: 2) { nodes { swiftCode } } }
+			de: country(iso2: "DE") { swiftCodes(first: 2) { nodes { swiftCode } } }
+			again: country(iso2: "PL") { swiftCodes(first: 2) { nodes { swiftCode } } }
+		}`, &data)
+		require.Empty(t, errs)
+		assert.Len(t, data.PL.SwiftCodes.Nodes, 2)
+		assert.Equal(t, "BBBBDEFFXXX", data.DE.SwiftCodes.Nodes[0].SwiftCode)
+		assert.Equal(t, data.PL, data.Again)
+		assert.Equal(t, int32(2), repo.countryQueries.Load())
+	})
+
+	t.Run("TestGraph_pagination", func(t *testing.T) {
+		type page struct {
+			Country struct {
+				SwiftCodes struct {
+					Nodes    []struct{ SwiftCode string }
+					PageInfo struct {
+						EndCursor   *string
+						HasNextPage bool
+					}
+				}
+			}
+		}
+		var first page
+		require.Empty(t, execGraph(t, schema, `{ country(iso2: "PL") { swiftCodes(first: 60) { nodes { swiftCode } pageInfo { endCursor hasNextPage } } } }`, &first))
+		require.True(t, first.Country.SwiftCodes.PageInfo.HasNextPage)
+		require.NotNil(t, first.Country.SwiftCodes.PageInfo.EndCursor)
+
+		var second page
+		query := fmt.Sprintf(`{ country(iso2: "PL") { swiftCodes(first: 60, after: %q) { nodes { swiftCode } pageInfo { endCursor hasNextPage } } } }`, *first.Country.SwiftCodes.PageInfo.EndCursor)
+		require.Empty(t, execGraph(t, schema, query, &second))
+		require.Len(t, second.Country.SwiftCodes.Nodes, 1)
+		assert.Equal(t, "ZZZZPLPWKRK", second.Country.SwiftCodes.Nodes[0].SwiftCode)
+		assert.False(t, second.Country.SwiftCodes.PageInfo.HasNextPage)
+		assert.Nil(t, second.Country.SwiftCodes.PageInfo.EndCursor)
+	})
+
+	t.Run("TestGraph_errors", func(t *testing.T) {
+		testCases := []struct {
+			query string
+			code  string
+		}{
+			{`{ swiftCode(code: "CCCCPLPWXXX") { swiftCode } }`, models.ErrorCodeNotFound},
+			{`{ swiftCode(code: "1234PLPWXXX") { swiftCode } }`, models.ErrorCodeValidation},
+			{`{ headquarter(code: "BKABPLPWKRK") { swiftCode } }`, models.ErrorCodeValidation},
+			{`{ country(iso2: "POL") { name } }`, models.ErrorCodeValidation},
+			{`{ country(iso2: "FR") { name } }`, models.ErrorCodeNotFound},
+			{`{ country(iso2: "PL") { swiftCodes(first: 0) { nodes { swiftCode } } } }`, models.ErrorCodeValidation},
+			{`{ country(iso2: "PL") { swiftCodes(after: "bm90IGEgY3Vyc29y") { nodes { swiftCode } } } }`, models.ErrorCodeValidation},
+		}
+
+		for _, tc := range testCases {
+			errs := execGraph(t, schema, tc.query, nil)
+			require.Len(t, errs, 1, tc.query)
+			assert.Equal(t, tc.code, errs[0].Extensions["code"], tc.query)
+		}
+	})
+
+	t.Run("TestGraph_internalError", func(t *testing.T) {
+		mockService := new(MockSwiftCodeService)
+		mockService.On("GetCountryName", "PL").Return("", fmt.Errorf("connection refused"))
+
+		errs := execGraph(t, graph.NewSchema(mockService), `{ country(iso2: "PL") { name } }`, nil)
+		require.Len(t, errs, 1)
+		assert.Equal(t, models.ErrorCodeInternal, errs[0].Extensions["code"])
+		assert.Equal(t, "internal error", errs[0].Message)
+	})
+}
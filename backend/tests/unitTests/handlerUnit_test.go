@@ -1,26 +1,38 @@
 package unitTests
 
 import (
+	"RemitlyTask/src/export"
 	"RemitlyTask/src/handlers"
+	"RemitlyTask/src/importer"
 	"RemitlyTask/src/models"
+	"RemitlyTask/src/repositories"
+	"RemitlyTask/src/router"
+	"RemitlyTask/src/services"
+	"RemitlyTask/src/validation"
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAddNewSwiftCode(t *testing.T) {
-	mockService := new(MockSwiftCodeService)
-	handler := handlers.NewSwiftCodeHandlerByService(mockService)
+	mockRepo := new(MockSwiftCodeRepository)
+	handler := handlers.NewSwiftCodeHandlerByService(services.NewSwiftCodeService(mockRepo))
 
 	gin.SetMode(gin.TestMode)
 	r := gin.Default()
+	r.Use(handlers.ErrorHandler(false))
 	r.POST("/swift-codes", handler.AddNewSwiftCode)
 
 	validCode := &models.SwiftCodeBranch{
@@ -29,13 +41,12 @@ func TestAddNewSwiftCode(t *testing.T) {
 		CountryISO2:   "PL",
 		CountryName:   "POLAND",
 		IsHeadquarter: false,
-		SwiftCode:     "TESTUSABXYZ",
+		SwiftCode:     "TESTPLABXYZ",
 	}
 
 	t.Run("TestAddNewSwiftCode_successful", func(t *testing.T) {
-		mockService.On("GetCountryName", "PL").Return("POLAND", nil)
-		mockService.On("GetBranchDetails", "TESTUSABXYZ").Return(nil, nil)
-		mockService.On("AddSwiftCode", mock.AnythingOfType("*models.SwiftCode")).Return(nil)
+		mockRepo.On("FindCountryNameByISO2", "PL").Return("POLAND", nil)
+		mockRepo.On("Create", mock.AnythingOfType("*models.SwiftCode")).Return(nil)
 
 		jsonData, err := json.Marshal(validCode)
 		assert.NoError(t, err)
@@ -50,7 +61,7 @@ func TestAddNewSwiftCode(t *testing.T) {
 		err = json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err)
 		assert.Equal(t, validCode.SwiftCode+" has been added to the database.", response["message"])
-		mockService.AssertExpectations(t)
+		mockRepo.AssertExpectations(t)
 	})
 
 	t.Run("TestAddNewSwiftCode_invalidIso2Length", func(t *testing.T) {
@@ -74,6 +85,44 @@ func TestAddNewSwiftCode(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
 
+	t.Run("TestAddNewSwiftCode_countryMismatch", func(t *testing.T) {
+		mismatchedCode := *validCode
+		mismatchedCode.SwiftCode = "TESTUSABXYZ"
+
+		jsonData, err := json.Marshal(mismatchedCode)
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/swift-codes", bytes.NewBuffer(jsonData))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var response models.Problem
+		err = json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, "countryISO2", response.Errors[0].Field)
+	})
+
+	t.Run("TestAddNewSwiftCode_invalidInstitutionCode", func(t *testing.T) {
+		invalidCode := *validCode
+		invalidCode.SwiftCode = "1234PLABXYZ"
+
+		jsonData, err := json.Marshal(invalidCode)
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/swift-codes", bytes.NewBuffer(jsonData))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var response models.Problem
+		err = json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, "institutionCode", response.Errors[0].Field)
+	})
+
 	t.Run("TestAddNewSwiftCode_invalidJSON", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest(http.MethodPost, "/swift-codes", bytes.NewBuffer([]byte(`{"invalid json"}`)))
@@ -90,6 +139,7 @@ func TestGetCode(t *testing.T) {
 
 	gin.SetMode(gin.TestMode)
 	r := gin.Default()
+	r.Use(handlers.ErrorHandler(false))
 	r.GET("/swift-codes/:swift-code", handler.GetCode)
 
 	branchCode := &models.SwiftCode{
@@ -136,6 +186,18 @@ func TestGetCode(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
 
+	t.Run("TestGetCode_lowercaseSwiftCode", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/swift-codes/testusabxyz", nil)
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var response models.Problem
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, "institutionCode", response.Errors[0].Field)
+	})
+
 	t.Run("TestGetCode_branchSuccessful", func(t *testing.T) {
 		mockService.On("GetBranchDetails", branchCode.SwiftCode).Return(branchCode, nil)
 
@@ -171,27 +233,30 @@ func TestGetCode(t *testing.T) {
 	t.Run("TestGetCode_notFound", func(t *testing.T) {
 		notFoundCode := "NOTFOUNDXXX"
 
-		mockService.On("GetHeadquarterDetails", notFoundCode[:8]).Return(nil, nil)
+		mockService.On("GetHeadquarterDetails", notFoundCode[:8]).Return(nil, models.ErrNotFound)
 
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest(http.MethodGet, "/swift-codes/"+notFoundCode, nil)
 		r.ServeHTTP(w, req)
 
 		assert.Equal(t, http.StatusNotFound, w.Code)
-		var response map[string]string
+		var response models.Problem
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err)
-		assert.Equal(t, "No SWIFT code found for: "+notFoundCode, response["message"])
+		assert.Equal(t, "No SWIFT code found for: "+notFoundCode, response.Detail)
+		assert.Equal(t, models.ErrorCodeNotFound, response.Code)
 		mockService.AssertExpectations(t)
 	})
 }
 
 func TestGetCodesByCountry(t *testing.T) {
+	defaultCountryQuery := models.CountryQuery{Limit: handlers.DefaultPageSize, SortBy: models.SortBySwiftCode}
 	mockService := new(MockSwiftCodeService)
 	handler := handlers.NewSwiftCodeHandlerByService(mockService)
 
 	gin.SetMode(gin.TestMode)
 	r := gin.Default()
+	r.Use(handlers.ErrorHandler(false))
 	r.GET("/swift-codes/country/:ISO2", handler.GetCodesByCountry)
 
 	expectedResponse := models.SwiftCodeCountry{
@@ -216,7 +281,7 @@ func TestGetCodesByCountry(t *testing.T) {
 	}
 
 	t.Run("TestGetCodesByCountry_successful", func(t *testing.T) {
-		mockService.On("GetSwiftCodesByCountry", "PL").Return(expectedResponse, nil)
+		mockService.On("GetSwiftCodesByCountry", "PL", defaultCountryQuery).Return(expectedResponse, nil)
 
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest(http.MethodGet, "/swift-codes/country/PL", nil)
@@ -232,6 +297,37 @@ func TestGetCodesByCountry(t *testing.T) {
 		mockService.AssertExpectations(t)
 	})
 
+	t.Run("TestGetCodesByCountry_queryParameters", func(t *testing.T) {
+		isHeadquarter := true
+		cursor := models.EncodeCursor(models.CountryCursor{SortBy: models.SortByBankName, Descending: true, Value: "B BANK", SwiftCode: "BBBBDEFFXXX"})
+		mockService.On("GetSwiftCodesByCountry", "DE", models.CountryQuery{
+			Limit:         10,
+			SortBy:        models.SortByBankName,
+			Descending:    true,
+			IsHeadquarter: &isHeadquarter,
+			Town:          "Berlin",
+			After:         &models.CountryCursor{SortBy: models.SortByBankName, Descending: true, Value: "B BANK", SwiftCode: "BBBBDEFFXXX"},
+		}).Return(models.SwiftCodeCountry{CountryISO2: "DE", CountryName: "GERMANY"}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/swift-codes/country/DE?limit=10&sort=bankName&order=desc&isHeadquarter=true&town=Berlin&cursor="+cursor, nil)
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("TestGetCodesByCountry_invalidQueryParameters", func(t *testing.T) {
+		otherSortCursor := models.EncodeCursor(models.CountryCursor{SortBy: models.SortBySwiftCode, SwiftCode: "BBBBDEFFXXX"})
+		for _, query := range []string{"limit=0", "limit=abc", "limit=1001", "sort=address", "order=up", "isHeadquarter=maybe", "cursor=not-a-cursor", "sort=bankName&cursor=" + otherSortCursor} {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/swift-codes/country/DE?"+query, nil)
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, query)
+		}
+	})
+
 	t.Run("TestGetCodesByCountry_invalidISO2Length", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest(http.MethodGet, "/swift-codes/country/USA", nil)
@@ -239,29 +335,25 @@ func TestGetCodesByCountry(t *testing.T) {
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 
-		var response map[string]string
+		var response models.Problem
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err)
-		assert.Contains(t, response["message"], "Invalid ISO2 code")
+		assert.Contains(t, response.Detail, "Invalid ISO2 code")
 	})
 
 	t.Run("TestGetCodesByCountry_NonExistentISO2", func(t *testing.T) {
-		emptyResponse := models.SwiftCodeCountry{
-			CountryISO2: "XX",
-			CountryName: "",
-			SwiftCodes:  []models.SwiftCodeBank{},
-		}
-		mockService.On("GetSwiftCodesByCountry", "XX").Return(emptyResponse, nil)
+		mockService.On("GetSwiftCodesByCountry", "XX", defaultCountryQuery).Return(nil, fmt.Errorf("country XX %w", models.ErrNotFound))
 
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest(http.MethodGet, "/swift-codes/country/XX", nil)
 		r.ServeHTTP(w, req)
 
-		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assertProblem(t, w, models.ErrorCodeNotFound, "No SWIFT code found for ISO2 code: XX")
 	})
 
 	t.Run("TestGetCodesByCountry_serviceError", func(t *testing.T) {
-		mockService.On("GetSwiftCodesByCountry", "FR").Return(models.SwiftCodeCountry{}, errors.New("service error"))
+		mockService.On("GetSwiftCodesByCountry", "FR", defaultCountryQuery).Return(models.SwiftCodeCountry{}, errors.New("service error"))
 
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest(http.MethodGet, "/swift-codes/country/FR", nil)
@@ -277,6 +369,7 @@ func TestDeleteCode(t *testing.T) {
 
 	gin.SetMode(gin.TestMode)
 	r := gin.Default()
+	r.Use(handlers.ErrorHandler(false))
 	r.DELETE("/swift-codes/:swift-code", handler.DeleteCode)
 
 	t.Run("TestDeleteCode_successful", func(t *testing.T) {
@@ -295,19 +388,1076 @@ func TestDeleteCode(t *testing.T) {
 		mockService.AssertExpectations(t)
 	})
 
+	t.Run("TestDeleteCode_invalidSwiftCode", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, "/swift-codes/12345678", nil)
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "DeleteSwiftCode", "12345678")
+	})
+
 	t.Run("TestDeleteCode_nonExistentCode", func(t *testing.T) {
 		swiftCode := "NONEXISTXXX"
-		mockService.On("DeleteSwiftCode", swiftCode).Return(errors.New("SWIFT code NONEXISTENT not found"))
+		mockService.On("DeleteSwiftCode", swiftCode).Return(fmt.Errorf("SWIFT code NONEXISTENT %w", models.ErrNotFound))
 
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest(http.MethodDelete, "/swift-codes/"+swiftCode, nil)
 		r.ServeHTTP(w, req)
 
 		assert.Equal(t, http.StatusNotFound, w.Code)
+		var response models.Problem
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, "Could not delete a record SWIFT code NONEXISTENT not found", response.Detail)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestReplaceCode(t *testing.T) {
+	mockService := new(MockSwiftCodeService)
+	handler := handlers.NewSwiftCodeHandlerByService(mockService)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.Use(handlers.ErrorHandler(false))
+	r.PUT("/swift-codes/:swift-code", handler.ReplaceCode)
+
+	t.Run("TestReplaceCode_clearsOmittedFields", func(t *testing.T) {
+		address, bankName, empty := "NEW ADDRESS", "NEW NAME", ""
+		mockService.On("UpdateSwiftCode", "TESTPLPWXXX", models.SwiftCodePatch{
+			Address:  &address,
+			BankName: &bankName,
+			TownName: &empty,
+			TimeZone: &empty,
+		}).Return(nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, "/swift-codes/TESTPLPWXXX", bytes.NewBufferString(`{"address":"NEW ADDRESS","bankName":"NEW NAME"}`))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("TestReplaceCode_notFound", func(t *testing.T) {
+		mockService.On("UpdateSwiftCode", "MISSPLPWXXX", mock.Anything).Return(models.ErrNotFound)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, "/swift-codes/MISSPLPWXXX", bytes.NewBufferString(`{"address":"A","bankName":"B"}`))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("TestReplaceCode_unknownField", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, "/swift-codes/UNKNPLPWXXX", bytes.NewBufferString(`{"address":"A","bankName":"B","isHeadquarter":true}`))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "isHeadquarter")
+		mockService.AssertNotCalled(t, "UpdateSwiftCode", "UNKNPLPWXXX", mock.Anything)
+	})
+}
+
+func TestPatchCode(t *testing.T) {
+	mockService := new(MockSwiftCodeService)
+	handler := handlers.NewSwiftCodeHandlerByService(mockService)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.Use(handlers.ErrorHandler(false))
+	r.PATCH("/swift-codes/:swift-code", handler.PatchCode)
+
+	t.Run("TestPatchCode_mergePatch", func(t *testing.T) {
+		address, empty := "NEW ADDRESS", ""
+		mockService.On("UpdateSwiftCode", "TESTPLPWXXX", models.SwiftCodePatch{
+			Address:  &address,
+			TimeZone: &empty,
+		}).Return(nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPatch, "/swift-codes/TESTPLPWXXX", bytes.NewBufferString(`{"address":"NEW ADDRESS","timeZone":null}`))
+		req.Header.Set("Content-Type", "application/merge-patch+json")
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
 		var response map[string]string
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err)
-		assert.Equal(t, "Could not delete a record SWIFT code NONEXISTENT not found", response["message"])
+		assert.Equal(t, "TESTPLPWXXX has been updated.", response["message"])
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("TestPatchCode_immutableCountry", func(t *testing.T) {
+		mockService.On("UpdateSwiftCode", "OTHRPLPWXXX", mock.Anything).Return(&validation.FieldError{Field: validation.FieldCountryISO2, Value: "DE", Err: validation.ErrImmutable})
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPatch, "/swift-codes/OTHRPLPWXXX", bytes.NewBufferString(`{"countryISO2":"DE"}`))
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var response models.Problem
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, "countryISO2", response.Errors[0].Field)
+	})
+
+	t.Run("TestPatchCode_unknownField", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPatch, "/swift-codes/TESTPLPWXXX", bytes.NewBufferString(`{"isHeadquarter":false}`))
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("TestPatchCode_notAnObject", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPatch, "/swift-codes/TESTPLPWXXX", bytes.NewBufferString(`["address"]`))
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestSearchCodes(t *testing.T) {
+	mockService := new(MockSwiftCodeService)
+	handler := handlers.NewSwiftCodeHandlerByService(mockService)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.Use(handlers.ErrorHandler(false))
+	r.GET("/swift-codes/search", handler.SearchCodes)
+
+	t.Run("TestSearchCodes_successful", func(t *testing.T) {
+		results := []models.SwiftCodeSearchResult{
+			{
+				SwiftCodeBank: models.SwiftCodeBank{
+					Address:       "VIA MILANO 1",
+					BankName:      "UNICREDIT S.P.A.",
+					CountryISO2:   "IT",
+					IsHeadquarter: true,
+					SwiftCode:     "UNCRITMMXXX",
+				},
+				Score: 0.9,
+			},
+		}
+		mockService.On("SearchSwiftCodes", "UniCredit Milan", "IT", 5).Return(results, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/swift-codes/search?q=UniCredit+Milan&country=it&limit=5", nil)
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response models.SwiftCodeSearch
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, models.SwiftCodeSearch{Query: "UniCredit Milan", Results: results}, response)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("TestSearchCodes_defaultLimit", func(t *testing.T) {
+		mockService.On("SearchSwiftCodes", "alior", "", handlers.DefaultSearchLimit).Return([]models.SwiftCodeSearchResult{}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/swift-codes/search?q=alior", nil)
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("TestSearchCodes_invalidParameters", func(t *testing.T) {
+		for _, query := range []string{"", "q=a", "q=alior&country=POL", "q=alior&limit=500"} {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/swift-codes/search?"+query, nil)
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, query)
+		}
+	})
+
+	t.Run("TestSearchCodes_serviceError", func(t *testing.T) {
+		mockService.On("SearchSwiftCodes", "broken", "", handlers.DefaultSearchLimit).Return([]models.SwiftCodeSearchResult{}, errors.New("service error"))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/swift-codes/search?q=broken", nil)
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestSuggestCodes(t *testing.T) {
+	mockService := new(MockSwiftCodeService)
+	handler := handlers.NewSwiftCodeHandlerByService(mockService)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.Use(handlers.ErrorHandler(false))
+	r.GET("/swift-codes/suggest", handler.SuggestCodes)
+
+	t.Run("TestSuggestCodes_successful", func(t *testing.T) {
+		suggestions := []models.SwiftCodeSuggestion{{SwiftCode: "ALBPPLPWXXX", BankName: "ALIOR BANK SPOLKA AKCYJNA", CountryISO2: "PL"}}
+		mockService.On("SuggestSwiftCodes", "ali", handlers.DefaultSuggestLimit).Return(suggestions, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/swift-codes/suggest?prefix=ali", nil)
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response models.SwiftCodeSuggestions
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, models.SwiftCodeSuggestions{Prefix: "ali", Suggestions: suggestions}, response)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("TestSuggestCodes_invalidParameters", func(t *testing.T) {
+		for _, query := range []string{"", "prefix=al", "prefix=alior&limit=51"} {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/swift-codes/suggest?"+query, nil)
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, query)
+		}
+	})
+}
+
+func TestLookupCodes(t *testing.T) {
+	mockService := new(MockSwiftCodeService)
+	handler := handlers.NewSwiftCodeHandlerByService(mockService)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.Use(handlers.ErrorHandler(false))
+	r.POST("/swift-codes/lookup", handler.LookupCodes)
+
+	t.Run("TestLookupCodes_successful", func(t *testing.T) {
+		results := []models.SwiftCodeLookupResult{
+			{SwiftCode: "ALBPPLPWCUS", Found: true, Details: models.SwiftCodeBranch{SwiftCode: "ALBPPLPWCUS", BankName: "ALIOR BANK", CountryISO2: "PL"}},
+			{SwiftCode: "MISSPLPWXXX", Error: &models.LookupError{Code: models.LookupErrorNotFound, Message: "SWIFT code MISSPLPWXXX not found"}},
+		}
+		mockService.On("LookupSwiftCodes", []string{"ALBPPLPWCUS", "MISSPLPWXXX"}).Return(results, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/swift-codes/lookup", bytes.NewBufferString(`["ALBPPLPWCUS","MISSPLPWXXX"]`))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response struct {
+			Results []struct {
+				SwiftCode string                 `json:"swiftCode"`
+				Found     bool                   `json:"found"`
+				Details   models.SwiftCodeBranch `json:"details"`
+				Error     *models.LookupError    `json:"error"`
+			} `json:"results"`
+		}
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Results, 2)
+		assert.Equal(t, "ALIOR BANK", response.Results[0].Details.BankName)
+		assert.Nil(t, response.Results[0].Error)
+		assert.Equal(t, models.LookupErrorNotFound, response.Results[1].Error.Code)
 		mockService.AssertExpectations(t)
 	})
+
+	t.Run("TestLookupCodes_invalidBody", func(t *testing.T) {
+		for _, body := range []string{`[]`, `{"swiftCodes":["ALBPPLPWXXX"]}`, `[1,2]`} {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/swift-codes/lookup", bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/json")
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, body)
+		}
+	})
+
+	t.Run("TestLookupCodes_tooManyCodes", func(t *testing.T) {
+		codes := make([]string, handlers.MaxLookupBatch+1)
+		for i := range codes {
+			codes[i] = "ALBPPLPWXXX"
+		}
+		body, err := json.Marshal(codes)
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/swift-codes/lookup", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestBulkAddSwiftCodes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	body := `[
+		{"address":"1 Test St","bankName":"TEST BANK","countryISO2":"PL","countryName":"POLAND","isHeadquarter":true,"swiftCode":"TESTPLPWXXX"},
+		{"address":"2 Test St","bankName":"TEST BANK","countryISO2":"PL","countryName":"POLAND","isHeadquarter":false,"swiftCode":"TESTPLPWKRK"},
+		{"address":"","bankName":"TEST BANK","countryISO2":"PL","countryName":"POLAND","isHeadquarter":false,"swiftCode":"TESTPLPWWAW"},
+		{"address":"1 Test St","bankName":"TEST BANK","countryISO2":"PL","countryName":"POLAND","isHeadquarter":true,"swiftCode":"TESTPLPWXXX"}
+	]`
+
+	newRouter := func() (*gin.Engine, *MockSwiftCodeRepository) {
+		mockRepo := new(MockSwiftCodeRepository)
+		handler := handlers.NewSwiftCodeHandlerByService(services.NewSwiftCodeService(mockRepo))
+		r := gin.Default()
+		r.Use(handlers.ErrorHandler(false))
+		r.POST("/swift-codes/bulk", handler.BulkAddSwiftCodes)
+		return r, mockRepo
+	}
+
+	post := func(r *gin.Engine, url, contentType, body string) (*httptest.ResponseRecorder, models.BulkResult) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, url, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", contentType)
+		r.ServeHTTP(w, req)
+
+		var response models.BulkResult
+		_ = json.Unmarshal(w.Body.Bytes(), &response)
+		return w, response
+	}
+
+	t.Run("TestBulkAddSwiftCodes_nonAtomic", func(t *testing.T) {
+		r, mockRepo := newRouter()
+		mockRepo.On("FindCountryNameByISO2", "PL").Return("POLAND", nil)
+		mockRepo.On("FindByBankCodes", []string{"TESTPLPW"}).Return([]models.SwiftCode{{SwiftCode: "TESTPLPWKRK"}}, nil)
+		mockRepo.On("CreateBatch", mock.MatchedBy(func(codes []*models.SwiftCode) bool {
+			return len(codes) == 1 && codes[0].SwiftCode == "TESTPLPWXXX"
+		})).Return(nil).Once()
+
+		w, response := post(r, "/swift-codes/bulk", "application/json", body)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.False(t, response.Atomic)
+		assert.Equal(t, 1, response.Created)
+		assert.Equal(t, 3, response.Rejected)
+		assert.Equal(t, models.BulkStatusCreated, response.Results[0].Status)
+		assert.Equal(t, "TESTPLPWXXX has been added to the database.", response.Results[0].Message)
+		assert.Equal(t, handlers.ErrFailedToInsert+handlers.ErrSwiftCodeExists, response.Results[1].Message)
+		assert.Equal(t, validation.FieldAddress, response.Results[2].Field)
+		assert.Equal(t, handlers.ErrFailedToInsert+handlers.ErrSwiftCodeRepeated, response.Results[3].Message)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("TestBulkAddSwiftCodes_nonAtomicBatchFails", func(t *testing.T) {
+		r, mockRepo := newRouter()
+		codes := `[
+			{"address":"1 Test St","bankName":"TEST BANK","countryISO2":"PL","countryName":"POLAND","isHeadquarter":true,"swiftCode":"TESTPLPWXXX"},
+			{"address":"2 Test St","bankName":"TEST BANK","countryISO2":"PL","countryName":"POLAND","isHeadquarter":false,"swiftCode":"TESTPLPWKRK"}
+		]`
+		mockRepo.On("FindCountryNameByISO2", "PL").Return("POLAND", nil).Once()
+		mockRepo.On("FindByBankCodes", []string{"TESTPLPW"}).Return([]models.SwiftCode{}, nil)
+		mockRepo.On("CreateBatch", mock.Anything).Return(models.ErrAlreadyExists).Once()
+		mockRepo.On("Create", mock.MatchedBy(func(code *models.SwiftCode) bool {
+			return code.SwiftCode == "TESTPLPWXXX"
+		})).Return(nil).Once()
+		mockRepo.On("Create", mock.MatchedBy(func(code *models.SwiftCode) bool {
+			return code.SwiftCode == "TESTPLPWKRK"
+		})).Return(models.ErrAlreadyExists).Once()
+
+		w, response := post(r, "/swift-codes/bulk", "application/json", codes)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, 1, response.Created)
+		assert.Equal(t, 1, response.Rejected)
+		assert.Equal(t, models.BulkStatusCreated, response.Results[0].Status)
+		assert.Equal(t, handlers.ErrFailedToInsert+handlers.ErrSwiftCodeExists, response.Results[1].Message)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("TestBulkAddSwiftCodes_atomicRejected", func(t *testing.T) {
+		r, mockRepo := newRouter()
+		mockRepo.On("FindCountryNameByISO2", "PL").Return("POLAND", nil)
+		mockRepo.On("FindByBankCodes", []string{"TESTPLPW"}).Return([]models.SwiftCode{}, nil)
+
+		w, response := post(r, "/swift-codes/bulk?atomic=true", "application/json", body)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.True(t, response.Atomic)
+		assert.Equal(t, 0, response.Created)
+		assert.Equal(t, models.BulkStatusSkipped, response.Results[0].Status)
+		assert.Equal(t, models.BulkStatusRejected, response.Results[2].Status)
+		mockRepo.AssertNotCalled(t, "CreateBatch", mock.Anything)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("TestBulkAddSwiftCodes_atomicNDJSON", func(t *testing.T) {
+		r, mockRepo := newRouter()
+		ndjson := `{"address":"1 Test St","bankName":"TEST BANK","countryISO2":"pl","countryName":"Poland","isHeadquarter":true,"swiftCode":"TESTPLPWXXX"}
+
+{"address":"2 Test St","bankName":"TEST BANK","countryISO2":"PL","countryName":"POLAND","isHeadquarter":false,"swiftCode":"TESTPLPWKRK"}
+`
+		mockRepo.On("FindCountryNameByISO2", "PL").Return("POLAND", nil)
+		mockRepo.On("FindByBankCodes", []string{"TESTPLPW"}).Return([]models.SwiftCode{}, nil)
+		mockRepo.On("CreateBatch", mock.MatchedBy(func(codes []*models.SwiftCode) bool {
+			return len(codes) == 2 && codes[1].SwiftCode == "TESTPLPWKRK"
+		})).Return(nil)
+
+		w, response := post(r, "/swift-codes/bulk?atomic=true", "application/x-ndjson", ndjson)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, 2, response.Created)
+		assert.Equal(t, models.BulkStatusCreated, response.Results[1].Status)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("TestBulkAddSwiftCodes_invalidRequest", func(t *testing.T) {
+		r, _ := newRouter()
+		for _, tc := range []struct{ url, contentType, body string }{
+			{"/swift-codes/bulk", "application/json", `[]`},
+			{"/swift-codes/bulk", "application/json", `{"swiftCode":"TESTPLPWXXX"}`},
+			{"/swift-codes/bulk?atomic=maybe", "application/json", body},
+			{"/swift-codes/bulk", "application/x-ndjson", "{\"swiftCode\":\"TESTPLPWXXX\"}\nnot json\n"},
+		} {
+			w, _ := post(r, tc.url, tc.contentType, tc.body)
+			assert.Equal(t, http.StatusBadRequest, w.Code, tc.body)
+		}
+	})
+}
+
+func TestExportCodes(t *testing.T) {
+	mockService := new(MockSwiftCodeService)
+	handler := handlers.NewSwiftCodeHandlerByService(mockService)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.Use(handlers.ErrorHandler(false))
+	r.GET("/swift-codes/export", handler.ExportCodes)
+
+	codes := []models.SwiftCode{
+		{CountryISO2: "PL", SwiftCode: "ALBPPLPWXXX", CodeType: "BIC11", Name: "ALIOR BANK SPOLKA AKCYJNA", Address: "LOPUSZANSKA 38 D, WARSZAWA", TownName: "WARSZAWA", CountryName: "POLAND", TimeZone: "Europe/Warsaw"},
+		{CountryISO2: "PL", SwiftCode: "ALBPPLPWCUS", CodeType: "BIC11", Name: "ALIOR BANK SPOLKA AKCYJNA", Address: "\"QUOTED\" ADDRESS", TownName: "WARSZAWA", CountryName: "POLAND", TimeZone: "Europe/Warsaw"},
+	}
+
+	get := func(url string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, url, nil)
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("TestExportCodes_csvCanBeImported", func(t *testing.T) {
+		mockService.On("ExportSwiftCodes", "PL").Return(codes, nil).Once()
+
+		w := get("/swift-codes/export?format=csv&country=pl")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, export.ContentType(export.FormatCSV), w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Header().Get("Content-Disposition"), "swift-codes-PL.csv")
+		rows, rejected, err := importer.ReadRows(strings.NewReader(w.Body.String()))
+		assert.NoError(t, err)
+		assert.Empty(t, rejected)
+		assert.Len(t, rows, 2)
+		assert.Equal(t, codes[1], rows[1].Code)
+	})
+
+	t.Run("TestExportCodes_json", func(t *testing.T) {
+		mockService.On("ExportSwiftCodes", "").Return(codes, nil).Once()
+
+		w := get("/swift-codes/export?format=json")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var records []export.Record
+		err := json.Unmarshal(w.Body.Bytes(), &records)
+		assert.NoError(t, err)
+		assert.Equal(t, []export.Record{export.NewRecord(codes[0]), export.NewRecord(codes[1])}, records)
+	})
+
+	t.Run("TestExportCodes_emptyJSON", func(t *testing.T) {
+		mockService.On("ExportSwiftCodes", "XX").Return([]models.SwiftCode{}, nil).Once()
+
+		w := get("/swift-codes/export?format=json&country=XX")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, "[]", w.Body.String())
+	})
+
+	t.Run("TestExportCodes_ndjson", func(t *testing.T) {
+		mockService.On("ExportSwiftCodes", "").Return(codes, nil).Once()
+
+		w := get("/swift-codes/export?format=ndjson")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+		assert.Len(t, lines, 2)
+		var record export.Record
+		err := json.Unmarshal([]byte(lines[1]), &record)
+		assert.NoError(t, err)
+		assert.Equal(t, "ALBPPLPWCUS", record.SwiftCode)
+	})
+
+	t.Run("TestExportCodes_serviceError", func(t *testing.T) {
+		mockService.On("ExportSwiftCodes", "DE").Return([]models.SwiftCode{}, errors.New("db error")).Once()
+
+		w := get("/swift-codes/export?country=DE")
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("TestExportCodes_invalidQuery", func(t *testing.T) {
+		for _, url := range []string{"/swift-codes/export?format=xml", "/swift-codes/export?country=POL"} {
+			w := get(url)
+			assert.Equal(t, http.StatusBadRequest, w.Code, url)
+		}
+	})
+
+	mockService.AssertExpectations(t)
+}
+
+func TestReadOnly(t *testing.T) {
+	mockService := new(MockSwiftCodeService)
+	handler := handlers.NewSwiftCodeHandlerByService(mockService)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.Use(handlers.ErrorHandler(false))
+	vCodes := r.Group("/swift-codes")
+	vCodes.GET("/:swift-code", handler.GetCode)
+	writes := vCodes.Group("", handlers.ReadOnly(r))
+	writes.POST("", handler.AddNewSwiftCode)
+	writes.DELETE("/:swift-code", handler.DeleteCode)
+
+	testCases := []struct {
+		method string
+		url    string
+		allow  string
+	}{
+		{method: http.MethodDelete, url: "/swift-codes/ALBPPLPWXXX", allow: "GET"},
+		{method: http.MethodPost, url: "/swift-codes", allow: ""},
+	}
+
+	for _, tc := range testCases {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(tc.method, tc.url, bytes.NewBufferString(`{}`))
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code, tc.url)
+		assert.Equal(t, []string{tc.allow}, w.Header().Values("Allow"))
+		assertProblem(t, w, models.ErrorCodeReadOnly, handlers.ErrReadOnly)
+	}
+	mockService.AssertNotCalled(t, "DeleteSwiftCode", mock.Anything)
+	mockService.AssertNotCalled(t, "AddSwiftCode", mock.Anything)
+}
+
+func TestRequestTimeout(t *testing.T) {
+	mockService := new(MockSwiftCodeService)
+	handler := handlers.NewSwiftCodeHandlerByService(mockService)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.Use(handlers.ErrorHandler(false))
+	r.Use(handlers.Timeout(time.Minute))
+	r.GET("/swift-codes/:swift-code", handler.GetCode)
+	r.DELETE("/swift-codes/:swift-code", handler.DeleteCode)
+	r.GET("/deadline", func(c *gin.Context) {
+		deadline, ok := c.Request.Context().Deadline()
+		assert.True(t, ok)
+		assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, 5*time.Second)
+		c.Status(http.StatusNoContent)
+	})
+
+	t.Run("TestRequestTimeout_setsDeadline", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/deadline", nil)
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("TestRequestTimeout_deadlineExceeded", func(t *testing.T) {
+		testCases := []struct {
+			method string
+			code   string
+			call   string
+		}{
+			{method: http.MethodGet, code: "TESTPLPWKRK", call: "GetBranchDetails"},
+			{method: http.MethodDelete, code: "TESTPLPWXXX", call: "DeleteSwiftCode"},
+		}
+
+		for _, tc := range testCases {
+			if tc.method == http.MethodGet {
+				mockService.On(tc.call, tc.code).Return(nil, context.DeadlineExceeded)
+			} else {
+				mockService.On(tc.call, tc.code).Return(context.DeadlineExceeded)
+			}
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tc.method, "/swift-codes/"+tc.code, nil)
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusGatewayTimeout, w.Code, tc.call)
+			assertProblem(t, w, models.ErrorCodeTimeout, handlers.ErrRequestTimeout)
+		}
+		mockService.AssertExpectations(t)
+	})
+}
+
+// slowExportService exports rows codes, waiting delay before each one like a
+// slow query or client would.
+type slowExportService struct {
+	services.ISwiftCodeService
+	rows  int
+	delay time.Duration
+}
+
+func (s slowExportService) ExportSwiftCodes(ctx context.Context, iso2 string, fn func(models.SwiftCode) error) error {
+	for i := range s.rows {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(s.delay):
+		}
+		if err := fn(models.SwiftCode{SwiftCode: fmt.Sprintf("TESTPL%05d", i), CountryISO2: "PL"}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestExportRequestTimeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	newRouter := func(service services.ISwiftCodeService) *gin.Engine {
+		handler := handlers.NewSwiftCodeHandlerByService(service)
+		r := gin.Default()
+		r.Use(handlers.ErrorHandler(false))
+		r.Use(handlers.Timeout(50 * time.Millisecond))
+		r.GET("/swift-codes/export", handler.ExportCodes)
+		return r
+	}
+
+	t.Run("TestExportRequestTimeout_streamsPastTimeout", func(t *testing.T) {
+		r := newRouter(slowExportService{rows: 200, delay: time.Millisecond})
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/swift-codes/export?format=ndjson", nil)
+		start := time.Now()
+		r.ServeHTTP(w, req)
+
+		assert.Greater(t, time.Since(start), 50*time.Millisecond)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Len(t, strings.Split(strings.TrimSpace(w.Body.String()), "\n"), 200)
+	})
+
+	t.Run("TestExportRequestTimeout_firstRowTooSlow", func(t *testing.T) {
+		r := newRouter(slowExportService{rows: 1, delay: time.Second})
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/swift-codes/export", nil)
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+		assertProblem(t, w, models.ErrorCodeTimeout, handlers.ErrRequestTimeout)
+	})
+
+	t.Run("TestExportRequestTimeout_clientDisconnects", func(t *testing.T) {
+		r := newRouter(slowExportService{rows: 200, delay: time.Millisecond})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(20*time.Millisecond, cancel)
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/swift-codes/export?format=ndjson", nil)
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Less(t, len(strings.Split(strings.TrimSpace(w.Body.String()), "\n")), 200)
+	})
+}
+
+func TestErrorHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	newRouter := func(service services.ISwiftCodeService) *gin.Engine {
+		handler := handlers.NewSwiftCodeHandlerByService(service)
+		r := gin.Default()
+		r.Use(handlers.ErrorHandler(false))
+		r.POST("/swift-codes", handler.AddNewSwiftCode)
+		r.DELETE("/swift-codes/:swift-code", handler.DeleteCode)
+		return r
+	}
+	newCode := `{"address":"PROSTA 18","bankName":"MBANK S.A.","countryISO2":"PL","countryName":"POLAND","isHeadquarter":true,"swiftCode":"BREXPLPWXXX"}`
+
+	t.Run("TestErrorHandler_alreadyExists", func(t *testing.T) {
+		mockRepo := new(MockSwiftCodeRepository)
+		mockRepo.On("FindCountryNameByISO2", "PL").Return("POLAND", nil)
+		mockRepo.On("Create", mock.AnythingOfType("*models.SwiftCode")).Return(fmt.Errorf("SWIFT code BREXPLPWXXX %w", models.ErrAlreadyExists))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/swift-codes", strings.NewReader(newCode))
+		req.Header.Set("Content-Type", "application/json")
+		newRouter(services.NewSwiftCodeService(mockRepo)).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assertProblem(t, w, models.ErrorCodeAlreadyExists, handlers.ErrFailedToInsert+handlers.ErrSwiftCodeExists)
+	})
+
+	t.Run("TestErrorHandler_countryConflict", func(t *testing.T) {
+		mockRepo := new(MockSwiftCodeRepository)
+		mockRepo.On("FindCountryNameByISO2", "PL").Return("POLSKA", nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/swift-codes", strings.NewReader(newCode))
+		req.Header.Set("Content-Type", "application/json")
+		newRouter(services.NewSwiftCodeService(mockRepo)).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assertProblem(t, w, models.ErrorCodeConflict, handlers.ErrFailedToInsert+handlers.ErrCountryMismatch)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("TestErrorHandler_validation", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/swift-codes", strings.NewReader(`{"swiftCode": 1}`))
+		req.Header.Set("Content-Type", "application/json")
+		newRouter(new(MockSwiftCodeService)).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var response models.Problem
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, models.ErrorCodeValidation, response.Code)
+	})
+
+	t.Run("TestErrorHandler_databaseError", func(t *testing.T) {
+		mockService := new(MockSwiftCodeService)
+		mockService.On("DeleteSwiftCode", "BREXPLPWXXX").Return(errors.New("connection refused"))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, "/swift-codes/BREXPLPWXXX", nil)
+		newRouter(mockService).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assertProblem(t, w, models.ErrorCodeInternal, handlers.ErrFailedToDelete+" BREXPLPWXXX")
+	})
+}
+
+func assertProblem(t *testing.T, w *httptest.ResponseRecorder, code, detail string) {
+	t.Helper()
+	assert.Equal(t, models.ProblemContentType, w.Header().Get("Content-Type"))
+	var problem models.Problem
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Equal(t, models.ProblemType(code), problem.Type)
+	assert.Equal(t, w.Code, problem.Status)
+	assert.Equal(t, code, problem.Code)
+	assert.Equal(t, detail, problem.Detail)
+	assert.Empty(t, problem.Message)
+}
+
+func TestProblemDocument(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	newRouter := func(legacyMessage bool) *gin.Engine {
+		handler := handlers.NewSwiftCodeHandlerByService(services.NewSwiftCodeService(new(MockSwiftCodeRepository)))
+		r := gin.Default()
+		r.Use(handlers.ErrorHandler(legacyMessage))
+		r.POST("/swift-codes", handler.AddNewSwiftCode)
+		return r
+	}
+	invalidCode := `{"address":"","bankName":"MBANK S.A.","countryISO2":"POL","countryName":"POLAND","isHeadquarter":false,"swiftCode":"BREXPLPWXXX"}`
+	detail := handlers.ErrFailedToInsert + `countryISO2 "POL" ` + validation.ErrInvalidISO2.Error()
+
+	t.Run("TestProblemDocument_fieldErrors", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/swift-codes?dryRun=true", strings.NewReader(invalidCode))
+		req.Header.Set("Content-Type", "application/json")
+		newRouter(false).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assertProblem(t, w, models.ErrorCodeValidation, detail)
+		var problem models.Problem
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		assert.Equal(t, "Invalid request", problem.Title)
+		assert.Equal(t, "/swift-codes?dryRun=true", problem.Instance)
+		assert.Equal(t, []models.FieldProblem{
+			{Field: validation.FieldCountryISO2, Value: "POL", Detail: validation.ErrInvalidISO2.Error()},
+			{Field: validation.FieldIsHeadquarter, Value: "false", Detail: validation.ErrHeadquarterMismatch.Error()},
+			{Field: validation.FieldAddress, Value: "", Detail: validation.ErrEmptyAddress.Error()},
+		}, problem.Errors)
+		assert.Empty(t, problem.Field)
+	})
+
+	t.Run("TestProblemDocument_legacyMessage", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/swift-codes", strings.NewReader(invalidCode))
+		req.Header.Set("Content-Type", "application/json")
+		newRouter(true).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var problem models.Problem
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		assert.Equal(t, detail, problem.Message)
+		assert.Equal(t, problem.Detail, problem.Message)
+		assert.Equal(t, validation.FieldCountryISO2, problem.Field)
+		assert.Len(t, problem.Errors, 3)
+	})
+
+	t.Run("TestProblemDocument_notFoundTitle", func(t *testing.T) {
+		handler := handlers.NewSwiftCodeHandlerByService(services.NewSwiftCodeService(repositories.NewMemorySwiftCodeRepository()))
+		r := router.New(handler, router.Options{})
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/v1/swift-codes/country/XX", nil)
+		r.ServeHTTP(w, req)
+
+		assertProblem(t, w, models.ErrorCodeNotFound, handlers.ErrNoSwiftCodeFound+"for ISO2 code: XX")
+		var problem models.Problem
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		assert.Equal(t, "Resource not found", problem.Title)
+	})
+}
+
+func TestCodeHistory(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler := handlers.NewSwiftCodeHandlerByService(services.NewSwiftCodeService(repositories.NewMemorySwiftCodeRepository()))
+	r := router.New(handler, router.Options{TrustedProxies: []string{"192.0.2.0/24"}})
+	untrusted := router.New(handler, router.Options{})
+
+	sendTo := func(r http.Handler, method, url, body string, header http.Header) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, url, strings.NewReader(body))
+		for name, values := range header {
+			req.Header.Set(name, values[0])
+		}
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+		return w
+	}
+	send := func(method, url, body string, header http.Header) *httptest.ResponseRecorder {
+		return sendTo(r, method, url, body, header)
+	}
+
+	t.Run("TestCodeHistory_recordsActor", func(t *testing.T) {
+		w := send(http.MethodPost, "/v1/swift-codes", `{"address":"NOWA 4","bankName":"GAMMA BANK","countryISO2":"PL","countryName":"POLAND","isHeadquarter":true,"swiftCode":"CCCCPLPWXXX"}`,
+			http.Header{handlers.HeaderActor: {"alice"}, handlers.HeaderRequestID: {"req-42"}})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, "req-42", w.Header().Get(handlers.HeaderRequestID))
+
+		w = send(http.MethodDelete, "/v1/swift-codes/CCCCPLPWXXX", "", http.Header{})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		generatedID := w.Header().Get(handlers.HeaderRequestID)
+		assert.Len(t, generatedID, 32)
+
+		w = send(http.MethodGet, "/v1/swift-codes/CCCCPLPWXXX/history", "", http.Header{})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var history models.SwiftCodeHistory
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &history))
+		require.Len(t, history.Entries, 2)
+		assert.Equal(t, models.AuditCreate, history.Entries[0].Operation)
+		assert.Equal(t, "alice", history.Entries[0].Actor)
+		assert.Equal(t, "req-42", history.Entries[0].RequestID)
+		assert.Equal(t, "GAMMA BANK", history.Entries[0].After.BankName)
+		assert.Equal(t, models.AuditDelete, history.Entries[1].Operation)
+		assert.Equal(t, "192.0.2.1", history.Entries[1].Actor)
+		assert.Equal(t, generatedID, history.Entries[1].RequestID)
+	})
+
+	t.Run("TestCodeHistory_untrustedActor", func(t *testing.T) {
+		w := sendTo(untrusted, http.MethodPost, "/v1/swift-codes", `{"address":"NOWA 5","bankName":"DELTA BANK","countryISO2":"PL","countryName":"POLAND","isHeadquarter":true,"swiftCode":"EEEEPLPWXXX"}`,
+			http.Header{handlers.HeaderActor: {"alice"}, "X-Forwarded-For": {"198.51.100.7"}})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = send(http.MethodGet, "/v1/swift-codes/EEEEPLPWXXX/history", "", http.Header{})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var history models.SwiftCodeHistory
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &history))
+		require.Len(t, history.Entries, 1)
+		assert.Equal(t, "192.0.2.1:1234", history.Entries[0].Actor)
+		assert.Equal(t, "alice", history.Entries[0].ClaimedActor)
+	})
+
+	t.Run("TestCodeHistory_forwardedClientIP", func(t *testing.T) {
+		w := send(http.MethodPost, "/v1/swift-codes", `{"address":"NOWA 6","bankName":"EPSILON BANK","countryISO2":"PL","countryName":"POLAND","isHeadquarter":true,"swiftCode":"FFFFPLPWXXX"}`,
+			http.Header{"X-Forwarded-For": {"198.51.100.7"}})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = send(http.MethodGet, "/v1/swift-codes/FFFFPLPWXXX/history", "", http.Header{})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var history models.SwiftCodeHistory
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &history))
+		require.Len(t, history.Entries, 1)
+		assert.Equal(t, "198.51.100.7", history.Entries[0].Actor)
+		assert.Empty(t, history.Entries[0].ClaimedActor)
+	})
+
+	t.Run("TestCodeHistory_notFound", func(t *testing.T) {
+		w := send(http.MethodGet, "/v1/swift-codes/DDDDPLPWXXX/history", "", http.Header{})
+		assertProblem(t, w, models.ErrorCodeNotFound, handlers.ErrFetchHistory+"DDDDPLPWXXX")
+	})
+
+	t.Run("TestCodeHistory_invalidCode", func(t *testing.T) {
+		w := send(http.MethodGet, "/v1/swift-codes/1234/history", "", http.Header{})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestRestoreCode(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler := handlers.NewSwiftCodeHandlerByService(services.NewSwiftCodeService(repositories.NewMemorySwiftCodeRepository()))
+	r := router.New(handler, router.Options{})
+
+	send := func(method, url, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, url, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := send(http.MethodPost, "/v1/swift-codes", `{"address":"NOWA 4","bankName":"GAMMA BANK","countryISO2":"PL","countryName":"POLAND","isHeadquarter":true,"swiftCode":"CCCCPLPWXXX"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	w = send(http.MethodDelete, "/v1/swift-codes/CCCCPLPWXXX", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	t.Run("TestRestoreCode_deletedIsHidden", func(t *testing.T) {
+		w := send(http.MethodGet, "/v1/swift-codes/CCCCPLPWXXX", "")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		w = send(http.MethodGet, "/v1/swift-codes/country/PL", "")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("TestRestoreCode_includeDeleted", func(t *testing.T) {
+		w := send(http.MethodGet, "/v1/swift-codes/CCCCPLPWXXX?includeDeleted=true", "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var details models.SwiftCodeDetails
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &details))
+		assert.Equal(t, "GAMMA BANK", details.BankName)
+		require.NotNil(t, details.DeletedAt)
+
+		w = send(http.MethodGet, "/v1/swift-codes/country/PL?includeDeleted=true", "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var country models.SwiftCodeCountry
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &country))
+		require.Len(t, country.SwiftCodes, 1)
+		assert.NotNil(t, country.SwiftCodes[0].DeletedAt)
+
+		w = send(http.MethodGet, "/v1/swift-codes/CCCCPLPWXXX?includeDeleted=yes", "")
+		assertProblem(t, w, models.ErrorCodeValidation, handlers.ErrInvalidQuery+"includeDeleted must be true or false.")
+	})
+
+	t.Run("TestRestoreCode_restores", func(t *testing.T) {
+		w := send(http.MethodPost, "/v1/swift-codes/CCCCPLPWXXX/restore", "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.JSONEq(t, `{"message":"CCCCPLPWXXX has been restored."}`, w.Body.String())
+
+		w = send(http.MethodGet, "/v1/swift-codes/CCCCPLPWXXX", "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.NotContains(t, w.Body.String(), "deletedAt")
+	})
+
+	t.Run("TestRestoreCode_notDeleted", func(t *testing.T) {
+		w := send(http.MethodPost, "/v1/swift-codes/CCCCPLPWXXX/restore", "")
+		assertProblem(t, w, models.ErrorCodeConflict, handlers.ErrFailedToRestore+"CCCCPLPWXXX is not deleted.")
+	})
+
+	t.Run("TestRestoreCode_notFound", func(t *testing.T) {
+		w := send(http.MethodPost, "/v1/swift-codes/DDDDPLPWXXX/restore", "")
+		assertProblem(t, w, models.ErrorCodeNotFound, handlers.ErrNoSwiftCodeFound+"for: DDDDPLPWXXX")
+	})
+}
+
+func TestDeleteCodeCascade(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler := handlers.NewSwiftCodeHandlerByService(services.NewSwiftCodeService(repositories.NewMemorySwiftCodeRepository()))
+	r := router.New(handler, router.Options{})
+
+	send := func(method, url, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, url, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	for _, code := range []string{"CCCCPLPWXXX", "CCCCPLPWKRK", "CCCCPLPWWAW"} {
+		body := fmt.Sprintf(`{"address":"NOWA 4","bankName":"GAMMA BANK","countryISO2":"PL","countryName":"POLAND","isHeadquarter":%t,"swiftCode":"%s"}`, strings.HasSuffix(code, "XXX"), code)
+		w := send(http.MethodPost, "/v1/swift-codes", body)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	}
+
+	t.Run("TestDeleteCodeCascade_refusedWithBranches", func(t *testing.T) {
+		w := send(http.MethodDelete, "/v1/swift-codes/CCCCPLPWXXX", "")
+		assertProblem(t, w, models.ErrorCodeConflict, handlers.ErrFailedToDelete+" CCCCPLPWXXX: "+handlers.ErrHasBranches)
+
+		w = send(http.MethodGet, "/v1/swift-codes/CCCCPLPWXXX", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("TestDeleteCodeCascade_invalidCascade", func(t *testing.T) {
+		w := send(http.MethodDelete, "/v1/swift-codes/CCCCPLPWXXX?cascade=yes", "")
+		assertProblem(t, w, models.ErrorCodeValidation, handlers.ErrInvalidQuery+"cascade must be true or false.")
+	})
+
+	t.Run("TestDeleteCodeCascade_removesBranches", func(t *testing.T) {
+		w := send(http.MethodDelete, "/v1/swift-codes/CCCCPLPWXXX?cascade=true", "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.JSONEq(t, `{"message":"CCCCPLPWXXX was removed with its branches.","removed":["CCCCPLPWKRK","CCCCPLPWWAW","CCCCPLPWXXX"]}`, w.Body.String())
+
+		for _, code := range []string{"CCCCPLPWXXX", "CCCCPLPWKRK", "CCCCPLPWWAW"} {
+			w = send(http.MethodGet, "/v1/swift-codes/"+code, "")
+			assert.Equal(t, http.StatusNotFound, w.Code, code)
+		}
+	})
+
+	t.Run("TestDeleteCodeCascade_notFound", func(t *testing.T) {
+		w := send(http.MethodDelete, "/v1/swift-codes/CCCCPLPWXXX?cascade=true", "")
+		assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+	})
+}
+
+func TestCodeAsOf(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler := handlers.NewSwiftCodeHandlerByService(services.NewSwiftCodeService(repositories.NewMemorySwiftCodeRepository()))
+	r := router.New(handler, router.Options{})
+
+	send := func(method, url, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, url, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := send(http.MethodPost, "/v1/swift-codes", `{"address":"NOWA 4","bankName":"GAMMA BANK","countryISO2":"PL","countryName":"POLAND","isHeadquarter":true,"swiftCode":"CCCCPLPWXXX"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	time.Sleep(5 * time.Millisecond)
+	created := time.Now().UTC().Format(time.RFC3339Nano)
+	time.Sleep(5 * time.Millisecond)
+	w = send(http.MethodPatch, "/v1/swift-codes/CCCCPLPWXXX", `{"bankName":"GAMMA BANK S.A."}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	t.Run("TestCodeAsOf_code", func(t *testing.T) {
+		w := send(http.MethodGet, "/v1/swift-codes/CCCCPLPWXXX?asOf="+created, "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var details models.SwiftCodeDetails
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &details))
+		assert.Equal(t, "GAMMA BANK", details.BankName)
+
+		w = send(http.MethodGet, "/v1/swift-codes/CCCCPLPWXXX", "")
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &details))
+		assert.Equal(t, "GAMMA BANK S.A.", details.BankName)
+	})
+
+	t.Run("TestCodeAsOf_country", func(t *testing.T) {
+		w := send(http.MethodGet, "/v1/swift-codes/country/PL?asOf="+created, "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var country models.SwiftCodeCountry
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &country))
+		require.Len(t, country.SwiftCodes, 1)
+		assert.Equal(t, "GAMMA BANK", country.SwiftCodes[0].BankName)
+
+		w = send(http.MethodGet, "/v1/swift-codes/country/PL?asOf=2026-01-01", "")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("TestCodeAsOf_invalid", func(t *testing.T) {
+		w := send(http.MethodGet, "/v1/swift-codes/CCCCPLPWXXX?asOf=01/07/2026", "")
+		assertProblem(t, w, models.ErrorCodeValidation, handlers.ErrInvalidQuery+"asOf must be a date such as 2026-07-01 or an RFC 3339 time.")
+
+		w = send(http.MethodGet, "/v1/swift-codes/CCCCPLPWXXX?asOf=2026-07-01&includeDeleted=true", "")
+		assertProblem(t, w, models.ErrorCodeValidation, handlers.ErrInvalidQuery+"asOf can't be combined with includeDeleted.")
+	})
 }
@@ -0,0 +1,212 @@
+package unitTests
+
+import (
+	"RemitlyTask/src/importer"
+	"RemitlyTask/src/models"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+const importHeader = "COUNTRY ISO2 CODE,SWIFT CODE,CODE TYPE,NAME,ADDRESS,TOWN NAME,COUNTRY NAME,TIME ZONE\n"
+
+func TestImport(t *testing.T) {
+	t.Run("TestImport_summary", func(t *testing.T) {
+		mockRepo := &MockSwiftCodeRepository{}
+		imp := importer.NewImporter(mockRepo)
+
+		existing := models.SwiftCode{
+			ID:          7,
+			CountryISO2: "PL",
+			SwiftCode:   "ALBPPLPWXXX",
+			CodeType:    "BIC11",
+			Name:        "ALIOR BANK SPOLKA AKCYJNA",
+			Address:     "LOPUSZANSKA 38 D WARSZAWA",
+			TownName:    "WARSZAWA",
+			CountryName: "POLAND",
+			TimeZone:    "Europe/Warsaw",
+		}
+		changed := existing
+		changed.SwiftCode = "ALBPPLPWCUS"
+		changed.Address = "OLD ADDRESS"
+
+		mockRepo.On("FindCountryNameByISO2", "PL").Return("POLAND", nil)
+		mockRepo.On("FindBySwiftCode", "ALBPPLPWXXX").Return(existing, nil)
+		mockRepo.On("FindBySwiftCode", "ALBPPLPWCUS").Return(changed, nil)
+		mockRepo.On("FindBySwiftCode", "BREXPLPWMBK").Return(models.SwiftCode{}, nil)
+		mockRepo.On("Upsert", mock.AnythingOfType("*models.SwiftCode")).Return(nil)
+
+		csv := importHeader +
+			"PL,ALBPPLPWXXX,BIC11,ALIOR BANK SPOLKA AKCYJNA,LOPUSZANSKA 38 D WARSZAWA,WARSZAWA,POLAND,Europe/Warsaw\n" +
+			"PL,ALBPPLPWCUS,BIC11,ALIOR BANK SPOLKA AKCYJNA,LOPUSZANSKA 38 D WARSZAWA,WARSZAWA,POLAND,Europe/Warsaw\n" +
+			"pl,BREXPLPWMBK,BIC11,MBANK S.A.,PROSTA 18 WARSZAWA,WARSZAWA,poland,Europe/Warsaw\n" +
+			"PL,12345678,BIC11,BAD BANK,SOMEWHERE,WARSZAWA,POLAND,Europe/Warsaw\n" +
+			"PL,BREXPLPWMBK,BIC11,MBANK S.A.,PROSTA 18 WARSZAWA,WARSZAWA,POLAND,Europe/Warsaw\n" +
+			"DE,DEUTPLFFXXX,BIC11,DEUTSCHE BANK,TAUNUSANLAGE 12,FRANKFURT,GERMANY,Europe/Berlin\n" +
+			"PL,TOOFEW\n"
+
+		summary, err := imp.Import(context.Background(), strings.NewReader(csv))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, summary.Count(importer.StatusInserted))
+		assert.Equal(t, 1, summary.Count(importer.StatusUpdated))
+		assert.Equal(t, 1, summary.Count(importer.StatusUnchanged))
+		assert.Equal(t, 4, summary.Count(importer.StatusRejected))
+
+		rejectedLines := []int{}
+		for _, row := range summary.Rows {
+			if row.Status == importer.StatusRejected {
+				rejectedLines = append(rejectedLines, row.Line)
+			}
+		}
+		assert.ElementsMatch(t, []int{5, 6, 7, 8}, rejectedLines)
+		mockRepo.AssertNumberOfCalls(t, "Upsert", 2)
+		mockRepo.AssertNumberOfCalls(t, "FindCountryNameByISO2", 1)
+	})
+
+	t.Run("TestImport_countryMismatch", func(t *testing.T) {
+		mockRepo := &MockSwiftCodeRepository{}
+		imp := importer.NewImporter(mockRepo)
+
+		mockRepo.On("FindCountryNameByISO2", "PL").Return("POLAND", nil)
+
+		csv := importHeader +
+			"PL,ALBPPLPWXXX,BIC11,ALIOR BANK SPOLKA AKCYJNA,LOPUSZANSKA 38 D WARSZAWA,WARSZAWA,POLSKA,Europe/Warsaw\n"
+
+		summary, err := imp.Import(context.Background(), strings.NewReader(csv))
+
+		assert.NoError(t, err)
+		require.Len(t, summary.Rows, 1)
+		assert.Equal(t, importer.StatusRejected, summary.Rows[0].Status)
+		assert.Equal(t, "country name POLSKA "+models.ErrConflict.Error(), summary.Rows[0].Reason)
+		mockRepo.AssertNotCalled(t, "Upsert", mock.Anything)
+	})
+
+	t.Run("TestImport_restoresDeletedCode", func(t *testing.T) {
+		for name, newRepo := range repositoryBackends(t) {
+			repo := newRepo()
+			ctx := context.Background()
+			seedRepository(t, repo)
+			require.NoError(t, repo.Delete(ctx, "BREXPLPWKRK"))
+
+			csv := importHeader + "PL,BREXPLPWKRK,BIC11,MBANK S.A.,KARMELICKA 7,KRAKOW,POLAND,Europe/Warsaw\n"
+			summary, err := importer.NewImporter(repo).Import(ctx, strings.NewReader(csv))
+
+			require.NoError(t, err, name)
+			require.Len(t, summary.Rows, 1, name)
+			assert.Equal(t, importer.StatusRestored, summary.Rows[0].Status, name)
+			code, err := repo.FindBySwiftCode(ctx, "BREXPLPWKRK")
+			require.NoError(t, err, name)
+			assert.Equal(t, "KARMELICKA 7", code.Address, name)
+
+			entries, err := repo.FindHistory(ctx, "BREXPLPWKRK")
+			require.NoError(t, err, name)
+			var operations []string
+			for _, entry := range entries {
+				operations = append(operations, entry.Operation)
+			}
+			assert.Equal(t, []string{models.AuditCreate, models.AuditDelete, models.AuditRestore}, operations, name)
+			assert.Equal(t, "KARMELICKA 7", entries[2].After.Address, name)
+		}
+	})
+
+	t.Run("TestImport_invalidHeader", func(t *testing.T) {
+		mockRepo := &MockSwiftCodeRepository{}
+		imp := importer.NewImporter(mockRepo)
+
+		_, err := imp.Import(context.Background(), strings.NewReader("SWIFT CODE,COUNTRY ISO2 CODE,CODE TYPE,NAME,ADDRESS,TOWN NAME,COUNTRY NAME,TIME ZONE\n"))
+
+		assert.Error(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestSync(t *testing.T) {
+	stored := []models.SwiftCode{
+		{CountryISO2: "PL", SwiftCode: "ALBPPLPWXXX", CodeType: "BIC11", Name: "ALIOR BANK SPOLKA AKCYJNA", Address: "LOPUSZANSKA 38 D", TownName: "WARSZAWA", CountryName: "POLAND", TimeZone: "Europe/Warsaw"},
+		{CountryISO2: "PL", SwiftCode: "ALBPPLPWCUS", CodeType: "BIC11", Name: "ALIOR BANK SPOLKA AKCYJNA", Address: "OLD ADDRESS", TownName: "WARSZAWA", CountryName: "POLAND", TimeZone: "Europe/Warsaw"},
+		{CountryISO2: "PL", SwiftCode: "BPKOPLPWXXX", CodeType: "BIC11", Name: "PKO BANK POLSKI", Address: "PULAWSKA 15", TownName: "WARSZAWA", CountryName: "POLAND", TimeZone: "Europe/Warsaw"},
+	}
+	csv := importHeader +
+		"PL,ALBPPLPWXXX,BIC11,ALIOR BANK SPOLKA AKCYJNA,LOPUSZANSKA 38 D,WARSZAWA,POLAND,Europe/Warsaw\n" +
+		"PL,ALBPPLPWCUS,BIC11,ALIOR BANK SPOLKA AKCYJNA,NEW ADDRESS,KRAKOW,POLAND,Europe/Warsaw\n" +
+		"PL,BREXPLPWMBK,BIC11,MBANK S.A.,PROSTA 18,WARSZAWA,POLAND,Europe/Warsaw\n"
+
+	t.Run("TestSync_dryRun", func(t *testing.T) {
+		mockRepo := &MockSwiftCodeRepository{}
+		imp := importer.NewImporter(mockRepo)
+
+		mockRepo.On("FindCountryNameByISO2", "PL").Return("POLAND", nil)
+		mockRepo.On("FindAll").Return(stored, nil)
+
+		report, err := imp.Sync(context.Background(), strings.NewReader(csv), true)
+
+		assert.NoError(t, err)
+		assert.True(t, report.DryRun)
+		assert.Len(t, report.Added, 1)
+		assert.Equal(t, "BREXPLPWMBK", report.Added[0].SwiftCode)
+		assert.Len(t, report.Removed, 1)
+		assert.Equal(t, "BPKOPLPWXXX", report.Removed[0].SwiftCode)
+		assert.Len(t, report.Modified, 1)
+		assert.Equal(t, []importer.FieldChange{
+			{Field: "address", Old: "OLD ADDRESS", New: "NEW ADDRESS"},
+			{Field: "townName", Old: "WARSZAWA", New: "KRAKOW"},
+		}, report.Modified[0].Changes)
+		mockRepo.AssertNotCalled(t, "ApplyChanges", mock.Anything, mock.Anything)
+	})
+
+	t.Run("TestSync_apply", func(t *testing.T) {
+		mockRepo := &MockSwiftCodeRepository{}
+		imp := importer.NewImporter(mockRepo)
+
+		mockRepo.On("FindCountryNameByISO2", "PL").Return("POLAND", nil)
+		mockRepo.On("FindAll").Return(stored, nil)
+		mockRepo.On("ApplyChanges", mock.MatchedBy(func(upserts []models.SwiftCode) bool {
+			return len(upserts) == 2 && upserts[0].SwiftCode == "ALBPPLPWCUS" && upserts[1].SwiftCode == "BREXPLPWMBK"
+		}), []string{"BPKOPLPWXXX"}).Return(nil)
+
+		_, err := imp.Sync(context.Background(), strings.NewReader(csv), false)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("TestSync_restoresDeletedCode", func(t *testing.T) {
+		mockRepo := &MockSwiftCodeRepository{}
+		imp := importer.NewImporter(mockRepo)
+
+		deleted := stored[2]
+		deleted.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+		mockRepo.On("FindCountryNameByISO2", "PL").Return("POLAND", nil)
+		mockRepo.On("FindAll").Return([]models.SwiftCode{deleted}, nil)
+
+		report, err := imp.Sync(context.Background(), strings.NewReader(importHeader+"PL,BPKOPLPWXXX,BIC11,PKO BANK POLSKI,PULAWSKA 15,WARSZAWA,POLAND,Europe/Warsaw\n"), true)
+
+		assert.NoError(t, err)
+		assert.Empty(t, report.Added)
+		assert.Empty(t, report.Removed)
+		require.Len(t, report.Restored, 1)
+		assert.Equal(t, "BPKOPLPWXXX", report.Restored[0].SwiftCode)
+		assert.Empty(t, report.Restored[0].Changes)
+	})
+
+	t.Run("TestSync_rejectedRowIsNotRemoved", func(t *testing.T) {
+		mockRepo := &MockSwiftCodeRepository{}
+		imp := importer.NewImporter(mockRepo)
+
+		mockRepo.On("FindCountryNameByISO2", "PL").Return("POLAND", nil)
+		mockRepo.On("FindAll").Return(stored[2:], nil)
+
+		report, err := imp.Sync(context.Background(), strings.NewReader(importHeader+"PL,BPKOPLPWXXX,BIC11,PKO BANK POLSKI,,WARSZAWA,POLAND,Europe/Warsaw\n"), true)
+
+		assert.NoError(t, err)
+		assert.Len(t, report.Rejected, 1)
+		assert.Empty(t, report.Removed)
+	})
+}
@@ -0,0 +1,113 @@
+package unitTests
+
+import (
+	"RemitlyTask/src/database"
+	"RemitlyTask/src/migrations"
+	"RemitlyTask/src/repositories"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	postgres, err := migrations.Load(migrations.DialectPostgres)
+	require.NoError(t, err)
+	sqlite, err := migrations.Load(migrations.DialectSQLite)
+	require.NoError(t, err)
+
+	require.Len(t, sqlite, len(postgres))
+	for i := range postgres {
+		assert.Equal(t, i+1, postgres[i].Version)
+		assert.Equal(t, postgres[i].Version, sqlite[i].Version)
+		assert.Equal(t, postgres[i].Name, sqlite[i].Name)
+	}
+
+	_, err = migrations.Load("mysql")
+	assert.Error(t, err)
+}
+
+func TestMigrator(t *testing.T) {
+	db, err := database.ConnectSQLite(":memory:")
+	require.NoError(t, err)
+	defer database.Close(db)
+
+	migrator, err := migrations.NewMigrator(db, migrations.DialectSQLite)
+	require.NoError(t, err)
+	all, err := migrations.Load(migrations.DialectSQLite)
+	require.NoError(t, err)
+
+	t.Run("TestMigrator_upAppliesPending", func(t *testing.T) {
+		applied, err := migrator.Up()
+		require.NoError(t, err)
+		assert.Len(t, applied, len(all))
+		assert.True(t, db.Migrator().HasTable("swift_codes"))
+
+		applied, err = migrator.Up()
+		require.NoError(t, err)
+		assert.Empty(t, applied)
+
+		statuses, err := migrator.Status()
+		require.NoError(t, err)
+		for _, status := range statuses {
+			assert.NotNil(t, status.AppliedAt, status.Name)
+		}
+	})
+
+	t.Run("TestMigrator_auditIsAppendOnly", func(t *testing.T) {
+		err := db.Exec(`INSERT INTO swift_code_audit (swift_code, operation, actor, recorded_at) VALUES ('ALBPPLPWXXX', 'create', 'alice', CURRENT_TIMESTAMP)`).Error
+		require.NoError(t, err)
+
+		assert.ErrorContains(t, db.Exec(`UPDATE swift_code_audit SET actor = 'mallory'`).Error, "append-only")
+		assert.ErrorContains(t, db.Exec(`DELETE FROM swift_code_audit`).Error, "append-only")
+	})
+
+	t.Run("TestMigrator_downRevertsNewestFirst", func(t *testing.T) {
+		reverted, err := migrator.Down(1)
+		require.NoError(t, err)
+		require.Len(t, reverted, 1)
+		assert.Equal(t, all[len(all)-1].Version, reverted[0].Version)
+
+		statuses, err := migrator.Status()
+		require.NoError(t, err)
+		assert.Nil(t, statuses[len(statuses)-1].AppliedAt)
+		assert.NotNil(t, statuses[0].AppliedAt)
+
+		reverted, err = migrator.Down(-1)
+		require.NoError(t, err)
+		assert.Len(t, reverted, len(all)-1)
+		assert.False(t, db.Migrator().HasTable("swift_codes"))
+	})
+
+	t.Run("TestMigrator_adoptsExistingSchema", func(t *testing.T) {
+		err := db.Exec(`CREATE TABLE swift_codes (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			country_iso2 CHAR(2) NOT NULL,
+			swift_code VARCHAR(11) NOT NULL UNIQUE,
+			code_type VARCHAR(5) NOT NULL,
+			name TEXT NOT NULL,
+			address TEXT,
+			town_name VARCHAR(60),
+			country_name VARCHAR(50),
+			time_zone VARCHAR(50)
+		)`).Error
+		require.NoError(t, err)
+		err = db.Exec(`INSERT INTO swift_codes (country_iso2, swift_code, code_type, name) VALUES ('PL', 'ALBPPLPWXXX', 'BIC11', 'ALIOR BANK')`).Error
+		require.NoError(t, err)
+
+		applied, err := migrator.Up()
+		require.NoError(t, err)
+		assert.Len(t, applied, len(all))
+
+		var count int64
+		require.NoError(t, db.Table("swift_codes").Count(&count).Error)
+		assert.Equal(t, int64(1), count)
+
+		repo := repositories.NewSQLiteSwiftCodeRepository(db)
+		code, err := repo.FindBySwiftCode(repositories.AsOf(context.Background(), time.Now().Add(time.Second)), "ALBPPLPWXXX")
+		require.NoError(t, err)
+		assert.Equal(t, "ALIOR BANK", code.Name)
+	})
+}
@@ -2,6 +2,8 @@ package unitTests
 
 import (
 	"RemitlyTask/src/models"
+	"context"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -10,32 +12,100 @@ type MockSwiftCodeRepository struct {
 	mock.Mock
 }
 
-func (m *MockSwiftCodeRepository) FindBySwiftCodePrefix(prefix string) ([]models.SwiftCode, error) {
+func (m *MockSwiftCodeRepository) FindBySwiftCodePrefix(ctx context.Context, prefix string) ([]models.SwiftCode, error) {
 	args := m.Called(prefix)
 	return args.Get(0).([]models.SwiftCode), args.Error(1)
 }
 
-func (m *MockSwiftCodeRepository) FindBySwiftCode(code string) (models.SwiftCode, error) {
+func (m *MockSwiftCodeRepository) FindBySwiftCode(ctx context.Context, code string) (models.SwiftCode, error) {
 	args := m.Called(code)
 	return args.Get(0).(models.SwiftCode), args.Error(1)
 }
 
-func (m *MockSwiftCodeRepository) FindCountryNameByISO2(iso2 string) (string, error) {
+func (m *MockSwiftCodeRepository) FindByBankCodes(ctx context.Context, bankCodes []string) ([]models.SwiftCode, error) {
+	args := m.Called(bankCodes)
+	return args.Get(0).([]models.SwiftCode), args.Error(1)
+}
+
+func (m *MockSwiftCodeRepository) FindCountryNameByISO2(ctx context.Context, iso2 string) (string, error) {
 	args := m.Called(iso2)
 	return args.String(0), args.Error(1)
 }
 
-func (m *MockSwiftCodeRepository) FindByCountryISO2(iso2 string) ([]models.SwiftCode, error) {
-	args := m.Called(iso2)
+func (m *MockSwiftCodeRepository) FindByCountryISO2(ctx context.Context, iso2 string, query models.CountryQuery) ([]models.SwiftCode, error) {
+	args := m.Called(iso2, query)
 	return args.Get(0).([]models.SwiftCode), args.Error(1)
 }
 
-func (m *MockSwiftCodeRepository) Create(newCode *models.SwiftCode) error {
+func (m *MockSwiftCodeRepository) Search(ctx context.Context, query string, iso2 string, limit int) ([]models.ScoredSwiftCode, error) {
+	args := m.Called(query, iso2, limit)
+	return args.Get(0).([]models.ScoredSwiftCode), args.Error(1)
+}
+
+func (m *MockSwiftCodeRepository) Create(ctx context.Context, newCode *models.SwiftCode) error {
 	args := m.Called(newCode)
 	return args.Error(0)
 }
 
-func (m *MockSwiftCodeRepository) Delete(swiftCode string) error {
+func (m *MockSwiftCodeRepository) CreateBatch(ctx context.Context, newCodes []*models.SwiftCode) error {
+	args := m.Called(newCodes)
+	return args.Error(0)
+}
+
+func (m *MockSwiftCodeRepository) Upsert(ctx context.Context, code *models.SwiftCode) error {
+	args := m.Called(code)
+	return args.Error(0)
+}
+
+func (m *MockSwiftCodeRepository) Update(ctx context.Context, code *models.SwiftCode) error {
+	args := m.Called(code)
+	return args.Error(0)
+}
+
+func (m *MockSwiftCodeRepository) FindAll(ctx context.Context) ([]models.SwiftCode, error) {
+	args := m.Called()
+	return args.Get(0).([]models.SwiftCode), args.Error(1)
+}
+
+func (m *MockSwiftCodeRepository) Iterate(ctx context.Context, iso2 string, fn func(models.SwiftCode) error) error {
+	args := m.Called(iso2)
+	for _, code := range args.Get(0).([]models.SwiftCode) {
+		if err := fn(code); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *MockSwiftCodeRepository) ApplyChanges(ctx context.Context, upserts []models.SwiftCode, removals []string) error {
+	args := m.Called(upserts, removals)
+	return args.Error(0)
+}
+
+func (m *MockSwiftCodeRepository) Delete(ctx context.Context, swiftCode string) error {
+	args := m.Called(swiftCode)
+	return args.Error(0)
+}
+
+func (m *MockSwiftCodeRepository) DeleteHeadquarter(ctx context.Context, swiftCode string, cascade bool) ([]string, error) {
+	args := m.Called(swiftCode, cascade)
+	if removed, ok := args.Get(0).([]string); ok {
+		return removed, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockSwiftCodeRepository) Restore(ctx context.Context, swiftCode string) error {
 	args := m.Called(swiftCode)
 	return args.Error(0)
 }
+
+func (m *MockSwiftCodeRepository) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
+	args := m.Called(deletedBefore)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockSwiftCodeRepository) FindHistory(ctx context.Context, swiftCode string) ([]models.AuditEntry, error) {
+	args := m.Called(swiftCode)
+	return args.Get(0).([]models.AuditEntry), args.Error(1)
+}
@@ -2,6 +2,8 @@ package unitTests
 
 import (
 	"RemitlyTask/src/models"
+	"context"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -10,32 +12,100 @@ type MockSwiftCodeService struct {
 	mock.Mock
 }
 
-func (m *MockSwiftCodeService) AddSwiftCode(newCode *models.SwiftCode) error {
+func (m *MockSwiftCodeService) ExportSwiftCodes(ctx context.Context, iso2 string, fn func(models.SwiftCode) error) error {
+	args := m.Called(iso2)
+	for _, code := range args.Get(0).([]models.SwiftCode) {
+		if err := fn(code); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *MockSwiftCodeService) AddSwiftCode(ctx context.Context, newCode *models.SwiftCode) error {
 	args := m.Called(newCode)
 	return args.Error(0)
 }
 
-func (m *MockSwiftCodeService) DeleteSwiftCode(swiftCode string) error {
+func (m *MockSwiftCodeService) UpdateSwiftCode(ctx context.Context, swiftCode string, patch models.SwiftCodePatch) error {
+	args := m.Called(swiftCode, patch)
+	return args.Error(0)
+}
+
+func (m *MockSwiftCodeService) ValidateNewSwiftCode(ctx context.Context, newCode models.SwiftCodeBranch) (models.SwiftCode, error) {
+	args := m.Called(newCode)
+	return args.Get(0).(models.SwiftCode), args.Error(1)
+}
+
+func (m *MockSwiftCodeService) ValidateNewSwiftCodes(ctx context.Context, newCodes []models.SwiftCodeBranch) ([]models.SwiftCode, []error) {
+	args := m.Called(newCodes)
+	return args.Get(0).([]models.SwiftCode), args.Get(1).([]error)
+}
+
+func (m *MockSwiftCodeService) AddSwiftCodes(ctx context.Context, newCodes []*models.SwiftCode) error {
+	args := m.Called(newCodes)
+	return args.Error(0)
+}
+
+func (m *MockSwiftCodeService) DeleteSwiftCode(ctx context.Context, swiftCode string) error {
 	args := m.Called(swiftCode)
 	return args.Error(0)
 }
 
-func (m *MockSwiftCodeService) GetSwiftCodesByCountry(iso2 string) (interface{}, error) {
-	args := m.Called(iso2)
+func (m *MockSwiftCodeService) DeleteSwiftCodeCascade(ctx context.Context, swiftCode string) ([]string, error) {
+	args := m.Called(swiftCode)
+	if removed, ok := args.Get(0).([]string); ok {
+		return removed, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockSwiftCodeService) RestoreSwiftCode(ctx context.Context, swiftCode string) error {
+	args := m.Called(swiftCode)
+	return args.Error(0)
+}
+
+func (m *MockSwiftCodeService) PurgeDeletedSwiftCodes(ctx context.Context, retention time.Duration) (int, error) {
+	args := m.Called(retention)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockSwiftCodeService) GetSwiftCodesByCountry(ctx context.Context, iso2 string, query models.CountryQuery) (interface{}, error) {
+	args := m.Called(iso2, query)
 	return args.Get(0), args.Error(1)
 }
 
-func (m *MockSwiftCodeService) GetHeadquarterDetails(swiftCodePrefix string) (interface{}, error) {
+func (m *MockSwiftCodeService) GetHeadquarterDetails(ctx context.Context, swiftCodePrefix string) (interface{}, error) {
 	args := m.Called(swiftCodePrefix)
 	return args.Get(0), args.Error(1)
 }
 
-func (m *MockSwiftCodeService) GetBranchDetails(swiftCode string) (interface{}, error) {
+func (m *MockSwiftCodeService) GetBranchDetails(ctx context.Context, swiftCode string) (interface{}, error) {
 	args := m.Called(swiftCode)
 	return args.Get(0), args.Error(1)
 }
 
-func (m *MockSwiftCodeService) GetCountryName(iso2 string) (string, error) {
+func (m *MockSwiftCodeService) SearchSwiftCodes(ctx context.Context, query string, iso2 string, limit int) ([]models.SwiftCodeSearchResult, error) {
+	args := m.Called(query, iso2, limit)
+	return args.Get(0).([]models.SwiftCodeSearchResult), args.Error(1)
+}
+
+func (m *MockSwiftCodeService) SuggestSwiftCodes(ctx context.Context, prefix string, limit int) ([]models.SwiftCodeSuggestion, error) {
+	args := m.Called(prefix, limit)
+	return args.Get(0).([]models.SwiftCodeSuggestion), args.Error(1)
+}
+
+func (m *MockSwiftCodeService) LookupSwiftCodes(ctx context.Context, swiftCodes []string) ([]models.SwiftCodeLookupResult, error) {
+	args := m.Called(swiftCodes)
+	return args.Get(0).([]models.SwiftCodeLookupResult), args.Error(1)
+}
+
+func (m *MockSwiftCodeService) GetSwiftCodeHistory(ctx context.Context, swiftCode string) (models.SwiftCodeHistory, error) {
+	args := m.Called(swiftCode)
+	return args.Get(0).(models.SwiftCodeHistory), args.Error(1)
+}
+
+func (m *MockSwiftCodeService) GetCountryName(ctx context.Context, iso2 string) (string, error) {
 	args := m.Called(iso2)
 	return args.String(0), args.Error(1)
 }
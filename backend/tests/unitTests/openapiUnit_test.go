@@ -0,0 +1,196 @@
+package unitTests
+
+import (
+	"RemitlyTask/src/handlers"
+	"RemitlyTask/src/models"
+	"RemitlyTask/src/openapi"
+	"RemitlyTask/src/repositories"
+	"RemitlyTask/src/router"
+	"RemitlyTask/src/services"
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type openAPIDocument struct {
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Responses map[string]openAPIResponse `json:"responses"`
+	} `json:"components"`
+}
+
+type openAPIOperation struct {
+	Responses map[string]openAPIResponse `json:"responses"`
+}
+
+type openAPIResponse struct {
+	Ref     string `json:"$ref"`
+	Content map[string]struct {
+		Schema json.RawMessage `json:"schema"`
+	} `json:"content"`
+}
+
+var ginParam = regexp.MustCompile(`[:*]([^/]+)`)
+
+// openAPIPath turns a gin route path into the path template of the document.
+func openAPIPath(path string) string {
+	return ginParam.ReplaceAllString(path, "{$1}")
+}
+
+func loadOpenAPI(t *testing.T) openAPIDocument {
+	var doc openAPIDocument
+	require.NoError(t, json.Unmarshal(openapi.Spec, &doc))
+	return doc
+}
+
+func TestOpenAPIRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	doc := loadOpenAPI(t)
+	r := router.New(handlers.NewSwiftCodeHandlerByService(new(MockSwiftCodeService)), router.Options{})
+
+	registered := make(map[string]bool)
+	for _, route := range r.Routes() {
+		path, method := openAPIPath(route.Path), strings.ToLower(route.Method)
+		registered[method+" "+path] = true
+		assert.Contains(t, doc.Paths[path], method, "route %s %s is not documented", route.Method, route.Path)
+	}
+
+	for path, item := range doc.Paths {
+		for method := range item {
+			if method == "parameters" {
+				continue
+			}
+			assert.True(t, registered[method+" "+path], "documented operation %s %s is not registered", method, path)
+		}
+	}
+}
+
+func TestOpenAPIResponses(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	doc := loadOpenAPI(t)
+
+	compiler := jsonschema.NewCompiler()
+	spec, err := jsonschema.UnmarshalJSON(bytes.NewReader(openapi.Spec))
+	require.NoError(t, err)
+	require.NoError(t, compiler.AddResource("openapi.json", spec))
+
+	repo := repositories.NewMemorySwiftCodeRepository()
+	require.NoError(t, repo.CreateBatch(context.Background(), []*models.SwiftCode{
+		{SwiftCode: "AAAAPLPWXXX", Name: "ALPHA BANK", Address: "PROSTA 1", CountryISO2: "PL", CountryName: "POLAND", TownName: "WARSZAWA"},
+		{SwiftCode: "AAAAPLPWKRK", Name: "ALPHA BANK", Address: "RYNEK 2", CountryISO2: "PL", CountryName: "POLAND", TownName: "KRAKOW"},
+		{SwiftCode: "BBBBDEFFXXX", Name: "BETA BANK", Address: "MAIN 3", CountryISO2: "DE", CountryName: "GERMANY", TownName: "FRANKFURT"},
+	}))
+	handler := handlers.NewSwiftCodeHandlerByService(services.NewSwiftCodeService(repo))
+	r := router.New(handler, router.Options{})
+	readOnly := router.New(handler, router.Options{ReadOnly: true})
+
+	newCode := `{"address":"NOWA 4","bankName":"GAMMA BANK","countryISO2":"PL","countryName":"POLAND","isHeadquarter":true,"swiftCode":"CCCCPLPWXXX"}`
+	testCases := []struct {
+		router      *gin.Engine
+		method      string
+		route       string
+		url         string
+		body        string
+		contentType string
+		status      int
+	}{
+		{r, http.MethodGet, "/v1/swift-codes/:swift-code", "/v1/swift-codes/AAAAPLPWXXX", "", "", http.StatusOK},
+		{r, http.MethodGet, "/v1/swift-codes/:swift-code", "/v1/swift-codes/BBBBDEFFXXX", "", "", http.StatusOK},
+		{r, http.MethodGet, "/v1/swift-codes/:swift-code", "/v1/swift-codes/AAAAPLPWKRK", "", "", http.StatusOK},
+		{r, http.MethodGet, "/v1/swift-codes/:swift-code", "/v1/swift-codes/CCCCPLPWXXX", "", "", http.StatusNotFound},
+		{r, http.MethodGet, "/v1/swift-codes/:swift-code", "/v1/swift-codes/INVALID", "", "", http.StatusBadRequest},
+		{r, http.MethodGet, "/v1/swift-codes/country/:ISO2", "/v1/swift-codes/country/PL?limit=1", "", "", http.StatusOK},
+		{r, http.MethodGet, "/v1/swift-codes/country/:ISO2", "/v1/swift-codes/country/POL", "", "", http.StatusBadRequest},
+		{r, http.MethodGet, "/v1/swift-codes/search", "/v1/swift-codes/search?q=alpha", "", "", http.StatusOK},
+		{r, http.MethodGet, "/v1/swift-codes/search", "/v1/swift-codes/search?q=zzzzzz", "", "", http.StatusOK},
+		{r, http.MethodGet, "/v1/swift-codes/suggest", "/v1/swift-codes/suggest?prefix=AAAA", "", "", http.StatusOK},
+		{r, http.MethodGet, "/v1/swift-codes/suggest", "/v1/swift-codes/suggest?prefix=ZZZZ", "", "", http.StatusOK},
+		{r, http.MethodGet, "/v1/swift-codes/export", "/v1/swift-codes/export?format=json", "", "", http.StatusOK},
+		{r, http.MethodGet, "/v1/swift-codes/export", "/v1/swift-codes/export?format=csv", "", "", http.StatusOK},
+		{r, http.MethodGet, "/v1/swift-codes/export", "/v1/swift-codes/export?format=xml", "", "", http.StatusBadRequest},
+		{r, http.MethodPost, "/v1/swift-codes/lookup", "/v1/swift-codes/lookup", `["AAAAPLPWXXX","AAAAPLPWKRK","12345678","CCCCPLPWXXX"]`, "application/json", http.StatusOK},
+		{r, http.MethodPost, "/v1/swift-codes", "/v1/swift-codes", newCode, "application/json", http.StatusOK},
+		{r, http.MethodPost, "/v1/swift-codes", "/v1/swift-codes", newCode, "application/json", http.StatusConflict},
+		{r, http.MethodPost, "/v1/swift-codes", "/v1/swift-codes", `{"swiftCode":"DDDDPLPWXXX","countryISO2":"P"}`, "application/json", http.StatusBadRequest},
+		{r, http.MethodPost, "/v1/swift-codes/bulk", "/v1/swift-codes/bulk?atomic=true", `[` + newCode + `]`, "application/json", http.StatusBadRequest},
+		{r, http.MethodPost, "/v1/swift-codes/bulk", "/v1/swift-codes/bulk", `[` + strings.ReplaceAll(newCode, "CCCC", "DDDD") + `]`, "application/json", http.StatusOK},
+		{r, http.MethodPut, "/v1/swift-codes/:swift-code", "/v1/swift-codes/AAAAPLPWKRK", `{"address":"RYNEK 3","bankName":"ALPHA BANK"}`, "application/json", http.StatusOK},
+		{r, http.MethodPatch, "/v1/swift-codes/:swift-code", "/v1/swift-codes/AAAAPLPWKRK", `{"townName":null}`, "application/merge-patch+json", http.StatusOK},
+		{r, http.MethodPatch, "/v1/swift-codes/:swift-code", "/v1/swift-codes/AAAAPLPWKRK", `{"isHeadquarter":true}`, "application/merge-patch+json", http.StatusBadRequest},
+		{r, http.MethodDelete, "/v1/swift-codes/:swift-code", "/v1/swift-codes/DDDDPLPWXXX", "", "", http.StatusOK},
+		{r, http.MethodDelete, "/v1/swift-codes/:swift-code", "/v1/swift-codes/DDDDPLPWXXX", "", "", http.StatusNotFound},
+		{r, http.MethodDelete, "/v1/swift-codes/:swift-code", "/v1/swift-codes/AAAAPLPWXXX", "", "", http.StatusConflict},
+		{r, http.MethodDelete, "/v1/swift-codes/:swift-code", "/v1/swift-codes/CCCCPLPWXXX?cascade=true", "", "", http.StatusOK},
+		{r, http.MethodGet, "/v1/swift-codes/:swift-code/history", "/v1/swift-codes/DDDDPLPWXXX/history", "", "", http.StatusOK},
+		{r, http.MethodGet, "/v1/swift-codes/:swift-code/history", "/v1/swift-codes/EEEEPLPWXXX/history", "", "", http.StatusNotFound},
+		{r, http.MethodGet, "/v1/swift-codes/:swift-code", "/v1/swift-codes/DDDDPLPWXXX?includeDeleted=true", "", "", http.StatusOK},
+		{r, http.MethodGet, "/v1/swift-codes/country/:ISO2", "/v1/swift-codes/country/PL?includeDeleted=true", "", "", http.StatusOK},
+		{r, http.MethodGet, "/v1/swift-codes/:swift-code", "/v1/swift-codes/AAAAPLPWXXX?asOf=2000-01-01", "", "", http.StatusNotFound},
+		{r, http.MethodGet, "/v1/swift-codes/:swift-code", "/v1/swift-codes/AAAAPLPWXXX?asOf=yesterday", "", "", http.StatusBadRequest},
+		{r, http.MethodGet, "/v1/swift-codes/country/:ISO2", "/v1/swift-codes/country/PL?asOf=2100-01-01T00:00:00Z", "", "", http.StatusOK},
+		{r, http.MethodPost, "/v1/swift-codes/:swift-code/restore", "/v1/swift-codes/DDDDPLPWXXX/restore", "", "", http.StatusOK},
+		{r, http.MethodPost, "/v1/swift-codes/:swift-code/restore", "/v1/swift-codes/DDDDPLPWXXX/restore", "", "", http.StatusConflict},
+		{r, http.MethodPost, "/v1/swift-codes/:swift-code/restore", "/v1/swift-codes/EEEEPLPWXXX/restore", "", "", http.StatusNotFound},
+		{readOnly, http.MethodPost, "/v1/swift-codes/:swift-code/restore", "/v1/swift-codes/AAAAPLPWKRK/restore", "", "", http.StatusMethodNotAllowed},
+		{readOnly, http.MethodDelete, "/v1/swift-codes/:swift-code", "/v1/swift-codes/AAAAPLPWKRK", "", "", http.StatusMethodNotAllowed},
+		{r, http.MethodPost, "/graphql", "/graphql", `{"query":"{ headquarter(code: \"AAAAPLPWXXX\") { branches { swiftCode } } }"}`, "application/json", http.StatusOK},
+		{r, http.MethodPost, "/graphql", "/graphql", `{"query":"{ swiftCode(code: \"CCCCPLPWXXX\") { swiftCode } }"}`, "application/json", http.StatusOK},
+		{r, http.MethodPost, "/graphql", "/graphql", `{}`, "application/json", http.StatusBadRequest},
+		{r, http.MethodGet, "/graphql", "/graphql?query=%7Bcountry(iso2%3A%22PL%22)%7Bname%7D%7D", "", "", http.StatusOK},
+		{r, http.MethodGet, "/openapi.json", "/openapi.json", "", "", http.StatusOK},
+		{r, http.MethodGet, "/docs/*file", "/docs/", "", "", http.StatusOK},
+	}
+
+	for _, tc := range testCases {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(tc.method, tc.url, strings.NewReader(tc.body))
+		if tc.contentType != "" {
+			req.Header.Set("Content-Type", tc.contentType)
+		}
+		tc.router.ServeHTTP(w, req)
+		require.Equal(t, tc.status, w.Code, "%s %s: %s", tc.method, tc.url, w.Body.String())
+
+		var operation openAPIOperation
+		path, method := openAPIPath(tc.route), strings.ToLower(tc.method)
+		require.NoError(t, json.Unmarshal(doc.Paths[path][method], &operation), "%s %s", tc.method, tc.url)
+
+		status := w.Result().Status[:3]
+		response, ok := operation.Responses[status]
+		require.True(t, ok, "%s %s: status %s is not documented", tc.method, tc.url, status)
+		pointer := "#/paths/" + escapePointer(path) + "/" + method + "/responses/" + status
+		if response.Ref != "" {
+			pointer = response.Ref
+			response = doc.Components.Responses[strings.TrimPrefix(response.Ref, "#/components/responses/")]
+		}
+
+		mediaType, _, err := mime.ParseMediaType(w.Header().Get("Content-Type"))
+		require.NoError(t, err, "%s %s", tc.method, tc.url)
+		require.Contains(t, response.Content, mediaType, "%s %s: media type %s is not documented", tc.method, tc.url, mediaType)
+		if mediaType != "application/json" && mediaType != models.ProblemContentType {
+			continue
+		}
+
+		schema, err := compiler.Compile("openapi.json" + pointer + "/content/" + escapePointer(mediaType) + "/schema")
+		require.NoError(t, err)
+		body, err := jsonschema.UnmarshalJSON(bytes.NewReader(w.Body.Bytes()))
+		require.NoError(t, err, "%s %s", tc.method, tc.url)
+		assert.NoError(t, schema.Validate(body), "%s %s: %s", tc.method, tc.url, w.Body.String())
+	}
+}
+
+// escapePointer escapes a JSON pointer token for use in a URI fragment.
+func escapePointer(token string) string {
+	return url.PathEscape(strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1"))
+}
@@ -0,0 +1,460 @@
+package unitTests
+
+import (
+	"RemitlyTask/src/config"
+	"RemitlyTask/src/database"
+	"RemitlyTask/src/models"
+	"RemitlyTask/src/repositories"
+	"RemitlyTask/src/storage"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// repositoryBackends are the repositories that need no external database.
+func repositoryBackends(t *testing.T) map[string]func() repositories.ISwiftCodeRepository {
+	return map[string]func() repositories.ISwiftCodeRepository{
+		"memory": func() repositories.ISwiftCodeRepository {
+			return repositories.NewMemorySwiftCodeRepository()
+		},
+		"sqlite": func() repositories.ISwiftCodeRepository {
+			db, err := database.OpenSQLite(":memory:")
+			require.NoError(t, err)
+			t.Cleanup(func() { database.Close(db) })
+			return repositories.NewSQLiteSwiftCodeRepository(db)
+		},
+	}
+}
+
+func seedRepository(t *testing.T, repo repositories.ISwiftCodeRepository) {
+	codes := []models.SwiftCode{
+		{SwiftCode: "ALBPPLPWXXX", Name: "ALIOR BANK SPOLKA AKCYJNA", Address: "LOPUSZANSKA 38 D", TownName: "WARSZAWA", CountryISO2: "PL", CountryName: "POLAND", CodeType: "BIC11"},
+		{SwiftCode: "ALBPPLPWCUS", Name: "ALIOR BANK SPOLKA AKCYJNA", Address: "LOPUSZANSKA 38 D", TownName: "WARSZAWA", CountryISO2: "PL", CountryName: "POLAND", CodeType: "BIC11"},
+		{SwiftCode: "BREXPLPWXXX", Name: "MBANK S.A.", Address: "PROSTA 18", TownName: "WARSZAWA", CountryISO2: "PL", CountryName: "POLAND", CodeType: "BIC11"},
+		{SwiftCode: "BREXPLPWKRK", Name: "MBANK S.A.", Address: "KARMELICKA 5", TownName: "KRAKOW", CountryISO2: "PL", CountryName: "POLAND", CodeType: "BIC11"},
+		{SwiftCode: "UNCRITMMXXX", Name: "UNICREDIT S.P.A.", Address: "PIAZZA GAE AULENTI 3", TownName: "MILANO", CountryISO2: "IT", CountryName: "ITALY", CodeType: "BIC11"},
+	}
+	for i := range codes {
+		require.NoError(t, repo.Create(context.Background(), &codes[i]))
+	}
+}
+
+func swiftCodesOf(codes []models.SwiftCode) []string {
+	result := []string{}
+	for _, code := range codes {
+		result = append(result, code.SwiftCode)
+	}
+	return result
+}
+
+func TestRepositoryBackends(t *testing.T) {
+	for name, newRepo := range repositoryBackends(t) {
+		t.Run(name, func(t *testing.T) {
+			t.Run("Lookups", func(t *testing.T) {
+				repo := newRepo()
+				seedRepository(t, repo)
+
+				code, err := repo.FindBySwiftCode(context.Background(), "BREXPLPWKRK")
+				assert.NoError(t, err)
+				assert.Equal(t, "KRAKOW", code.TownName)
+				assert.NotZero(t, code.ID)
+
+				missing, err := repo.FindBySwiftCode(context.Background(), "MISSPLPWXXX")
+				assert.NoError(t, err)
+				assert.Empty(t, missing.SwiftCode)
+
+				byPrefix, err := repo.FindBySwiftCodePrefix(context.Background(), "ALBPPLPW")
+				assert.NoError(t, err)
+				assert.ElementsMatch(t, []string{"ALBPPLPWXXX", "ALBPPLPWCUS"}, swiftCodesOf(byPrefix))
+
+				byBank, err := repo.FindByBankCodes(context.Background(), []string{"UNCRITMM", "BREXPLPW"})
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"BREXPLPWKRK", "BREXPLPWXXX", "UNCRITMMXXX"}, swiftCodesOf(byBank))
+
+				countryName, err := repo.FindCountryNameByISO2(context.Background(), "IT")
+				assert.NoError(t, err)
+				assert.Equal(t, "ITALY", countryName)
+			})
+
+			t.Run("CountryQuery", func(t *testing.T) {
+				repo := newRepo()
+				seedRepository(t, repo)
+
+				page, err := repo.FindByCountryISO2(context.Background(), "PL", models.CountryQuery{Limit: 2})
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"ALBPPLPWCUS", "ALBPPLPWXXX"}, swiftCodesOf(page))
+
+				after := &models.CountryCursor{SortBy: models.SortBySwiftCode, SwiftCode: "ALBPPLPWXXX"}
+				page, err = repo.FindByCountryISO2(context.Background(), "PL", models.CountryQuery{Limit: 2, After: after})
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"BREXPLPWKRK", "BREXPLPWXXX"}, swiftCodesOf(page))
+
+				byTown, err := repo.FindByCountryISO2(context.Background(), "PL", models.CountryQuery{SortBy: models.SortByTownName, Descending: true})
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"BREXPLPWXXX", "ALBPPLPWXXX", "ALBPPLPWCUS", "BREXPLPWKRK"}, swiftCodesOf(byTown))
+
+				after = &models.CountryCursor{SortBy: models.SortByBankName, Value: "ALIOR BANK SPOLKA AKCYJNA", SwiftCode: "ALBPPLPWXXX"}
+				byName, err := repo.FindByCountryISO2(context.Background(), "PL", models.CountryQuery{SortBy: models.SortByBankName, After: after})
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"BREXPLPWKRK", "BREXPLPWXXX"}, swiftCodesOf(byName))
+
+				headquarter := true
+				filtered, err := repo.FindByCountryISO2(context.Background(), "PL", models.CountryQuery{IsHeadquarter: &headquarter, Town: "warszawa"})
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"ALBPPLPWXXX", "BREXPLPWXXX"}, swiftCodesOf(filtered))
+			})
+
+			t.Run("Search", func(t *testing.T) {
+				repo := newRepo()
+				seedRepository(t, repo)
+
+				results, err := repo.Search(context.Background(), "alior warszwa", "", 10)
+				assert.NoError(t, err)
+				require.Len(t, results, 2)
+				assert.Equal(t, "ALBPPLPWCUS", results[0].Code.SwiftCode)
+				assert.Greater(t, results[0].Score, 0.6)
+
+				results, err = repo.Search(context.Background(), "unicredit milan", "", 10)
+				assert.NoError(t, err)
+				require.Len(t, results, 1)
+				assert.Equal(t, "UNCRITMMXXX", results[0].Code.SwiftCode)
+
+				results, err = repo.Search(context.Background(), "alior", "IT", 10)
+				assert.NoError(t, err)
+				assert.Empty(t, results)
+			})
+
+			t.Run("Writes", func(t *testing.T) {
+				repo := newRepo()
+				seedRepository(t, repo)
+
+				assert.ErrorIs(t, repo.Create(context.Background(), &models.SwiftCode{SwiftCode: "ALBPPLPWXXX", Name: "DUPLICATE", CountryISO2: "PL", CodeType: "BIC11"}), models.ErrAlreadyExists)
+
+				batch := []*models.SwiftCode{
+					{SwiftCode: "PKOPPLPWXXX", Name: "PKO BP", CountryISO2: "PL", CodeType: "BIC11"},
+					{SwiftCode: "BREXPLPWXXX", Name: "DUPLICATE", CountryISO2: "PL", CodeType: "BIC11"},
+				}
+				assert.ErrorIs(t, repo.CreateBatch(context.Background(), batch), models.ErrAlreadyExists)
+				notCreated, err := repo.FindBySwiftCode(context.Background(), "PKOPPLPWXXX")
+				assert.NoError(t, err)
+				assert.Empty(t, notCreated.SwiftCode)
+
+				assert.NoError(t, repo.CreateBatch(context.Background(), batch[:1]))
+
+				assert.NoError(t, repo.Update(context.Background(), &models.SwiftCode{SwiftCode: "PKOPPLPWXXX", Name: "PKO BANK POLSKI", Address: "PULAWSKA 15"}))
+				updated, err := repo.FindBySwiftCode(context.Background(), "PKOPPLPWXXX")
+				assert.NoError(t, err)
+				assert.Equal(t, "PKO BANK POLSKI", updated.Name)
+				assert.Equal(t, "PL", updated.CountryISO2)
+				assert.ErrorIs(t, repo.Update(context.Background(), &models.SwiftCode{SwiftCode: "MISSPLPWXXX"}), models.ErrNotFound)
+
+				assert.NoError(t, repo.Upsert(context.Background(), &models.SwiftCode{SwiftCode: "PKOPPLPWXXX", Name: "PKO BP SA", CountryISO2: "PL", CodeType: "BIC11"}))
+				upserted, err := repo.FindBySwiftCode(context.Background(), "PKOPPLPWXXX")
+				assert.NoError(t, err)
+				assert.Equal(t, "PKO BP SA", upserted.Name)
+
+				err = repo.ApplyChanges(context.Background(), []models.SwiftCode{
+					{SwiftCode: "UNCRITMMXXX", Name: "UNICREDIT", CountryISO2: "IT", CountryName: "ITALY", CodeType: "BIC11"},
+					{SwiftCode: "BCITITMMXXX", Name: "INTESA SANPAOLO", CountryISO2: "IT", CountryName: "ITALY", CodeType: "BIC11"},
+				}, []string{"ALBPPLPWCUS"})
+				assert.NoError(t, err)
+
+				var italian []string
+				err = repo.Iterate(context.Background(), "IT", func(code models.SwiftCode) error {
+					italian = append(italian, code.SwiftCode+" "+code.Name)
+					return nil
+				})
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"BCITITMMXXX INTESA SANPAOLO", "UNCRITMMXXX UNICREDIT"}, italian)
+
+				assert.NoError(t, repo.Delete(context.Background(), "BREXPLPWKRK"))
+				assert.ErrorIs(t, repo.Delete(context.Background(), "BREXPLPWKRK"), models.ErrNotFound)
+
+				all, err := repo.FindAll(context.Background())
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"ALBPPLPWXXX", "BCITITMMXXX", "BREXPLPWXXX", "PKOPPLPWXXX", "UNCRITMMXXX"}, swiftCodesOf(all))
+			})
+
+			t.Run("SoftDelete", func(t *testing.T) {
+				repo := newRepo()
+				seedRepository(t, repo)
+				ctx := context.Background()
+				withDeleted := repositories.WithDeleted(ctx)
+
+				require.NoError(t, repo.Delete(ctx, "BREXPLPWKRK"))
+				require.NoError(t, repo.Delete(ctx, "UNCRITMMXXX"))
+
+				code, err := repo.FindBySwiftCode(ctx, "BREXPLPWKRK")
+				require.NoError(t, err)
+				assert.Empty(t, code.SwiftCode)
+				code, err = repo.FindBySwiftCode(withDeleted, "BREXPLPWKRK")
+				require.NoError(t, err)
+				assert.Equal(t, "KARMELICKA 5", code.Address)
+				assert.True(t, code.DeletedAt.Valid)
+
+				byPrefix, err := repo.FindBySwiftCodePrefix(ctx, "BREXPLPW")
+				require.NoError(t, err)
+				assert.Equal(t, []string{"BREXPLPWXXX"}, swiftCodesOf(byPrefix))
+				byBank, err := repo.FindByBankCodes(withDeleted, []string{"BREXPLPW"})
+				require.NoError(t, err)
+				assert.Equal(t, []string{"BREXPLPWKRK", "BREXPLPWXXX"}, swiftCodesOf(byBank))
+				page, err := repo.FindByCountryISO2(ctx, "PL", models.CountryQuery{})
+				require.NoError(t, err)
+				assert.Equal(t, []string{"ALBPPLPWCUS", "ALBPPLPWXXX", "BREXPLPWXXX"}, swiftCodesOf(page))
+				all, err := repo.FindAll(ctx)
+				require.NoError(t, err)
+				assert.Len(t, all, 3)
+				all, err = repo.FindAll(withDeleted)
+				require.NoError(t, err)
+				assert.Len(t, all, 5)
+
+				countryName, err := repo.FindCountryNameByISO2(ctx, "IT")
+				require.NoError(t, err)
+				assert.Empty(t, countryName)
+				countryName, err = repo.FindCountryNameByISO2(withDeleted, "IT")
+				require.NoError(t, err)
+				assert.Equal(t, "ITALY", countryName)
+
+				assert.ErrorIs(t, repo.Delete(ctx, "BREXPLPWKRK"), models.ErrNotFound)
+				assert.ErrorIs(t, repo.Update(ctx, &models.SwiftCode{SwiftCode: "BREXPLPWKRK", Name: "MBANK", Address: "NOWA 1"}), models.ErrNotFound)
+				err = repo.Create(ctx, &models.SwiftCode{SwiftCode: "BREXPLPWKRK", Name: "MBANK", Address: "NOWA 1", CountryISO2: "PL", CountryName: "POLAND", CodeType: "BIC11"})
+				assert.ErrorIs(t, err, models.ErrAlreadyExists)
+				assert.ErrorContains(t, err, "restore it instead")
+
+				require.NoError(t, repo.Restore(ctx, "BREXPLPWKRK"))
+				code, err = repo.FindBySwiftCode(ctx, "BREXPLPWKRK")
+				require.NoError(t, err)
+				assert.Equal(t, "KARMELICKA 5", code.Address)
+				assert.False(t, code.DeletedAt.Valid)
+				assert.ErrorIs(t, repo.Restore(ctx, "BREXPLPWKRK"), models.ErrConflict)
+				assert.ErrorIs(t, repo.Restore(ctx, "MISSPLPWXXX"), models.ErrNotFound)
+
+				unicredit := models.SwiftCode{SwiftCode: "UNCRITMMXXX", Name: "UNICREDIT", Address: "PIAZZA GAE AULENTI 3", CountryISO2: "IT", CountryName: "ITALY", CodeType: "BIC11"}
+				require.NoError(t, repo.Upsert(ctx, &unicredit))
+				code, err = repo.FindBySwiftCode(ctx, "UNCRITMMXXX")
+				require.NoError(t, err)
+				assert.Equal(t, "UNICREDIT", code.Name)
+			})
+
+			t.Run("DeleteHeadquarter", func(t *testing.T) {
+				repo := newRepo()
+				seedRepository(t, repo)
+				ctx := context.Background()
+
+				_, err := repo.DeleteHeadquarter(ctx, "BREXPLPWXXX", false)
+				assert.ErrorIs(t, err, models.ErrConflict)
+				byPrefix, err := repo.FindBySwiftCodePrefix(ctx, "BREXPLPW")
+				require.NoError(t, err)
+				assert.ElementsMatch(t, []string{"BREXPLPWKRK", "BREXPLPWXXX"}, swiftCodesOf(byPrefix))
+
+				require.NoError(t, repo.Delete(ctx, "ALBPPLPWXXX"))
+				_, err = repo.DeleteHeadquarter(ctx, "ALBPPLPWXXX", true)
+				assert.ErrorIs(t, err, models.ErrNotFound)
+				byPrefix, err = repo.FindBySwiftCodePrefix(ctx, "ALBPPLPW")
+				require.NoError(t, err)
+				assert.Equal(t, []string{"ALBPPLPWCUS"}, swiftCodesOf(byPrefix))
+
+				removed, err := repo.DeleteHeadquarter(ctx, "UNCRITMMXXX", false)
+				require.NoError(t, err)
+				assert.Equal(t, []string{"UNCRITMMXXX"}, removed)
+
+				removed, err = repo.DeleteHeadquarter(ctx, "BREXPLPWXXX", true)
+				require.NoError(t, err)
+				assert.Equal(t, []string{"BREXPLPWKRK", "BREXPLPWXXX"}, removed)
+				byPrefix, err = repo.FindBySwiftCodePrefix(ctx, "BREXPLPW")
+				require.NoError(t, err)
+				assert.Empty(t, byPrefix)
+				byPrefix, err = repo.FindBySwiftCodePrefix(repositories.WithDeleted(ctx), "BREXPLPW")
+				require.NoError(t, err)
+				assert.Len(t, byPrefix, 2)
+				_, err = repo.DeleteHeadquarter(ctx, "BREXPLPWXXX", true)
+				assert.ErrorIs(t, err, models.ErrNotFound)
+
+				entries, err := repo.FindHistory(ctx, "BREXPLPWXXX")
+				require.NoError(t, err)
+				require.Len(t, entries, 2)
+				assert.Equal(t, models.AuditDelete, entries[1].Operation)
+				assert.Nil(t, entries[1].After)
+			})
+
+			t.Run("Purge", func(t *testing.T) {
+				repo := newRepo()
+				seedRepository(t, repo)
+				ctx := repositories.WithActor(context.Background(), repositories.Actor{Name: "purge"})
+
+				require.NoError(t, repo.Delete(ctx, "BREXPLPWKRK"))
+				require.NoError(t, repo.ApplyChanges(ctx, nil, []string{"ALBPPLPWCUS"}))
+
+				purged, err := repo.Purge(ctx, time.Now().Add(-time.Hour))
+				require.NoError(t, err)
+				assert.Zero(t, purged)
+
+				purged, err = repo.Purge(ctx, time.Now().Add(time.Second))
+				require.NoError(t, err)
+				assert.Equal(t, 2, purged)
+
+				all, err := repo.FindAll(repositories.WithDeleted(ctx))
+				require.NoError(t, err)
+				assert.Equal(t, []string{"ALBPPLPWXXX", "BREXPLPWXXX", "UNCRITMMXXX"}, swiftCodesOf(all))
+				assert.ErrorIs(t, repo.Restore(ctx, "BREXPLPWKRK"), models.ErrNotFound)
+
+				entries, err := repo.FindHistory(ctx, "BREXPLPWKRK")
+				require.NoError(t, err)
+				require.Len(t, entries, 3)
+				last := entries[2]
+				assert.Equal(t, models.AuditPurge, last.Operation)
+				assert.Equal(t, "purge", last.Actor)
+				require.NotNil(t, last.Before)
+				assert.Equal(t, "KARMELICKA 5", last.Before.Address)
+				assert.Nil(t, last.After)
+
+				require.NoError(t, repo.Create(ctx, &models.SwiftCode{SwiftCode: "BREXPLPWKRK", Name: "MBANK", Address: "NOWA 1", CountryISO2: "PL", CountryName: "POLAND", CodeType: "BIC11"}))
+			})
+
+			t.Run("AsOf", func(t *testing.T) {
+				repo := newRepo()
+				ctx := context.Background()
+				// tick returns a time between the writes made before and after it.
+				tick := func() time.Time {
+					time.Sleep(5 * time.Millisecond)
+					t := time.Now()
+					time.Sleep(5 * time.Millisecond)
+					return t
+				}
+
+				beforeSeed := tick()
+				seedRepository(t, repo)
+				seeded := tick()
+				require.NoError(t, repo.Update(ctx, &models.SwiftCode{SwiftCode: "BREXPLPWKRK", Name: "MBANK", Address: "RYNEK 1", TownName: "KRAKOW"}))
+				updated := tick()
+				require.NoError(t, repo.Delete(ctx, "BREXPLPWKRK"))
+				require.NoError(t, repo.ApplyChanges(ctx, []models.SwiftCode{
+					{SwiftCode: "UNCRITMMXXX", Name: "UNICREDIT", Address: "PIAZZA GAE AULENTI 3", TownName: "MILANO", CountryISO2: "IT", CountryName: "ITALY", CodeType: "BIC11"},
+				}, []string{"ALBPPLPWCUS"}))
+				deleted := tick()
+				require.NoError(t, repo.Restore(ctx, "BREXPLPWKRK"))
+				_, err := repo.Purge(ctx, time.Now().Add(time.Second))
+				require.NoError(t, err)
+
+				code, err := repo.FindBySwiftCode(repositories.AsOf(ctx, seeded), "BREXPLPWKRK")
+				require.NoError(t, err)
+				assert.Equal(t, "KARMELICKA 5", code.Address)
+				assert.Equal(t, "MBANK S.A.", code.Name)
+				code, err = repo.FindBySwiftCode(repositories.AsOf(ctx, updated), "BREXPLPWKRK")
+				require.NoError(t, err)
+				assert.Equal(t, "RYNEK 1", code.Address)
+				code, err = repo.FindBySwiftCode(repositories.AsOf(ctx, deleted), "BREXPLPWKRK")
+				require.NoError(t, err)
+				assert.Empty(t, code.SwiftCode)
+				code, err = repo.FindBySwiftCode(repositories.AsOf(ctx, beforeSeed), "BREXPLPWKRK")
+				require.NoError(t, err)
+				assert.Empty(t, code.SwiftCode)
+
+				byPrefix, err := repo.FindBySwiftCodePrefix(repositories.AsOf(ctx, updated), "ALBPPLPW")
+				require.NoError(t, err)
+				assert.ElementsMatch(t, []string{"ALBPPLPWXXX", "ALBPPLPWCUS"}, swiftCodesOf(byPrefix))
+				byPrefix, err = repo.FindBySwiftCodePrefix(repositories.AsOf(ctx, time.Now()), "ALBPPLPW")
+				require.NoError(t, err)
+				assert.Equal(t, []string{"ALBPPLPWXXX"}, swiftCodesOf(byPrefix))
+
+				page, err := repo.FindByCountryISO2(repositories.AsOf(ctx, deleted), "PL", models.CountryQuery{SortBy: models.SortByTownName, Limit: 2})
+				require.NoError(t, err)
+				assert.Equal(t, []string{"ALBPPLPWXXX", "BREXPLPWXXX"}, swiftCodesOf(page))
+				page, err = repo.FindByCountryISO2(repositories.AsOf(ctx, updated), "PL", models.CountryQuery{Town: "krakow"})
+				require.NoError(t, err)
+				assert.Equal(t, []string{"BREXPLPWKRK"}, swiftCodesOf(page))
+
+				countryName, err := repo.FindCountryNameByISO2(repositories.AsOf(ctx, beforeSeed), "IT")
+				require.NoError(t, err)
+				assert.Empty(t, countryName)
+				countryName, err = repo.FindCountryNameByISO2(repositories.AsOf(ctx, seeded), "IT")
+				require.NoError(t, err)
+				assert.Equal(t, "ITALY", countryName)
+
+				all, err := repo.FindAll(repositories.AsOf(ctx, seeded))
+				require.NoError(t, err)
+				assert.Len(t, all, 5)
+				var names []string
+				require.NoError(t, repo.Iterate(repositories.AsOf(ctx, deleted), "IT", func(code models.SwiftCode) error {
+					names = append(names, code.Name)
+					return nil
+				}))
+				assert.Equal(t, []string{"UNICREDIT"}, names)
+			})
+
+			t.Run("History", func(t *testing.T) {
+				repo := newRepo()
+				ctx := repositories.WithActor(context.Background(), repositories.Actor{Name: "alice", RequestID: "req-1"})
+				code := models.SwiftCode{SwiftCode: "PKOPPLPWXXX", Name: "PKO BP", Address: "PULAWSKA 15", CountryISO2: "PL", CountryName: "POLAND", CodeType: "BIC11"}
+
+				require.NoError(t, repo.Create(ctx, &code))
+				require.NoError(t, repo.Update(ctx, &models.SwiftCode{SwiftCode: "PKOPPLPWXXX", Name: "PKO BANK POLSKI", Address: "PULAWSKA 15"}))
+				unchanged, err := repo.FindBySwiftCode(ctx, "PKOPPLPWXXX")
+				require.NoError(t, err)
+				require.NoError(t, repo.Upsert(context.Background(), &unchanged))
+				require.NoError(t, repo.ApplyChanges(context.Background(), nil, []string{"PKOPPLPWXXX", "MISSPLPWXXX"}))
+				require.NoError(t, repo.Upsert(ctx, &code))
+				require.NoError(t, repo.Delete(ctx, "PKOPPLPWXXX"))
+
+				entries, err := repo.FindHistory(context.Background(), "PKOPPLPWXXX")
+				require.NoError(t, err)
+				require.Len(t, entries, 5)
+
+				var operations, actors []string
+				for _, entry := range entries {
+					operations = append(operations, entry.Operation)
+					actors = append(actors, entry.Actor)
+				}
+				assert.Equal(t, []string{models.AuditCreate, models.AuditUpdate, models.AuditDelete, models.AuditRestore, models.AuditDelete}, operations)
+				assert.Equal(t, []string{"alice", "alice", repositories.SystemActor, "alice", "alice"}, actors)
+				assert.Equal(t, "req-1", entries[0].RequestID)
+				assert.False(t, entries[0].Timestamp.IsZero())
+				assert.Less(t, entries[0].ID, entries[1].ID)
+
+				assert.Nil(t, entries[0].Before)
+				require.NotNil(t, entries[0].After)
+				assert.Equal(t, "PKO BP", entries[0].After.BankName)
+				assert.Equal(t, "PKO BP", entries[1].Before.BankName)
+				assert.Equal(t, "PKO BANK POLSKI", entries[1].After.BankName)
+				assert.Equal(t, "POLAND", entries[1].After.CountryName)
+				assert.Equal(t, "PKO BANK POLSKI", entries[2].Before.BankName)
+				assert.Nil(t, entries[2].After)
+
+				entries, err = repo.FindHistory(context.Background(), "MISSPLPWXXX")
+				require.NoError(t, err)
+				assert.Empty(t, entries)
+			})
+		})
+	}
+}
+
+func TestEmbeddedStorage(t *testing.T) {
+	cfg := config.Default()
+	cfg.Storage.Driver = config.StorageEmbedded
+
+	repo, closeRepo, err := storage.Open(context.Background(), cfg)
+	require.NoError(t, err)
+	defer closeRepo()
+
+	code, err := repo.FindBySwiftCode(context.Background(), "ALBPPLPWXXX")
+	assert.NoError(t, err)
+	assert.Equal(t, "ALIOR BANK SPOLKA AKCYJNA", code.Name)
+
+	countryName, err := repo.FindCountryNameByISO2(context.Background(), "PL")
+	assert.NoError(t, err)
+	assert.Equal(t, "POLAND", countryName)
+
+	assert.ErrorIs(t, repo.Create(context.Background(), &models.SwiftCode{SwiftCode: "TESTPLPWXXX"}), repositories.ErrReadOnly)
+	assert.ErrorIs(t, repo.CreateBatch(context.Background(), []*models.SwiftCode{{SwiftCode: "TESTPLPWXXX"}}), repositories.ErrReadOnly)
+	assert.ErrorIs(t, repo.Upsert(context.Background(), &code), repositories.ErrReadOnly)
+	assert.ErrorIs(t, repo.Update(context.Background(), &code), repositories.ErrReadOnly)
+	assert.ErrorIs(t, repo.ApplyChanges(context.Background(), nil, []string{"ALBPPLPWXXX"}), repositories.ErrReadOnly)
+	assert.ErrorIs(t, repo.Delete(context.Background(), "ALBPPLPWXXX"), repositories.ErrReadOnly)
+
+	stillThere, err := repo.FindBySwiftCode(context.Background(), "ALBPPLPWXXX")
+	assert.NoError(t, err)
+	assert.Equal(t, "ALBPPLPWXXX", stillThere.SwiftCode)
+}
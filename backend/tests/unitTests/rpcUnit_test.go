@@ -0,0 +1,242 @@
+package unitTests
+
+import (
+	"RemitlyTask/src/models"
+	"RemitlyTask/src/repositories"
+	"RemitlyTask/src/rpc"
+	"RemitlyTask/src/rpc/swiftcodespb"
+	"RemitlyTask/src/services"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newRPCClient serves the gRPC server of service over an in-memory
+// connection and returns a client of it.
+func newRPCClient(t *testing.T, service services.ISwiftCodeService, opts rpc.Options) swiftcodespb.SwiftCodesClient {
+	listener := bufconn.Listen(1 << 20)
+	server := rpc.NewServer(service, opts)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return swiftcodespb.NewSwiftCodesClient(conn)
+}
+
+func TestRPCServer(t *testing.T) {
+	ctx := context.Background()
+	repo := repositories.NewMemorySwiftCodeRepository()
+	seed := []*models.SwiftCode{
+		{SwiftCode: "AAAAPLPWXXX", Name: "ALPHA BANK", Address: "PROSTA 1", CountryISO2: "PL", CountryName: "POLAND", TownName: "WARSZAWA"},
+		{SwiftCode: "AAAAPLPWKRK", Name: "ALPHA BANK", Address: "RYNEK 2", CountryISO2: "PL", CountryName: "POLAND", TownName: "KRAKOW"},
+		{SwiftCode: "BBBBDEFFXXX", Name: "BETA BANK", Address: "MAIN 3", CountryISO2: "DE", CountryName: "GERMANY", TownName: "FRANKFURT"},
+	}
+	for i := 0; i < 600; i++ {
+		seed = append(seed, &models.SwiftCode{SwiftCode: fmt.Sprintf("CCCCPLPW%03d", i), Name: "GAMMA BANK", Address: "NOWA 4", CountryISO2: "PL", CountryName: "POLAND"})
+	}
+	require.NoError(t, repo.CreateBatch(ctx, seed))
+	client := newRPCClient(t, services.NewSwiftCodeService(repo), rpc.Options{})
+
+	t.Run("TestRPCServer_getHeadquarter", func(t *testing.T) {
+		details, err := client.GetCode(ctx, &swiftcodespb.GetCodeRequest{SwiftCode: "AAAAPLPWXXX"})
+		require.NoError(t, err)
+		assert.True(t, details.GetIsHeadquarter())
+		assert.Equal(t, "POLAND", details.GetCountryName())
+		require.Len(t, details.GetBranches(), 1)
+		assert.Equal(t, "AAAAPLPWKRK", details.GetBranches()[0].GetSwiftCode())
+	})
+
+	t.Run("TestRPCServer_getBranch", func(t *testing.T) {
+		details, err := client.GetCode(ctx, &swiftcodespb.GetCodeRequest{SwiftCode: "AAAAPLPWKRK"})
+		require.NoError(t, err)
+		assert.False(t, details.GetIsHeadquarter())
+		assert.Equal(t, "RYNEK 2", details.GetAddress())
+		assert.Empty(t, details.GetBranches())
+	})
+
+	t.Run("TestRPCServer_notFound", func(t *testing.T) {
+		_, err := client.GetCode(ctx, &swiftcodespb.GetCodeRequest{SwiftCode: "DDDDPLPWXXX"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("TestRPCServer_invalidCode", func(t *testing.T) {
+		_, err := client.GetCode(ctx, &swiftcodespb.GetCodeRequest{SwiftCode: "1234PLPWXXX"})
+		st := status.Convert(err)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+		require.Len(t, st.Details(), 1)
+		assert.Equal(t, "institutionCode", st.Details()[0].(*errdetails.BadRequest).GetFieldViolations()[0].GetField())
+	})
+
+	t.Run("TestRPCServer_listByCountry", func(t *testing.T) {
+		stream, err := client.ListByCountry(ctx, &swiftcodespb.ListByCountryRequest{CountryIso2: "pl"})
+		require.NoError(t, err)
+
+		var received []string
+		for {
+			code, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			require.NoError(t, err)
+			received = append(received, code.GetSwiftCode())
+		}
+		assert.Len(t, received, 602)
+		assert.Equal(t, "AAAAPLPWKRK", received[0])
+		assert.Equal(t, "CCCCPLPW599", received[601])
+	})
+
+	t.Run("TestRPCServer_listByCountryFiltered", func(t *testing.T) {
+		headquarters := true
+		stream, err := client.ListByCountry(ctx, &swiftcodespb.ListByCountryRequest{
+			CountryIso2:   "PL",
+			SortBy:        swiftcodespb.ListByCountryRequest_SORT_BY_BANK_NAME,
+			IsHeadquarter: &headquarters,
+		})
+		require.NoError(t, err)
+		code, err := stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, "AAAAPLPWXXX", code.GetSwiftCode())
+		_, err = stream.Recv()
+		assert.ErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("TestRPCServer_listUnknownCountry", func(t *testing.T) {
+		stream, err := client.ListByCountry(ctx, &swiftcodespb.ListByCountryRequest{CountryIso2: "XX"})
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("TestRPCServer_createAndDelete", func(t *testing.T) {
+		req := &swiftcodespb.CreateRequest{Address: "NOWA 5", BankName: "DELTA BANK", CountryIso2: "PL", CountryName: "POLAND", IsHeadquarter: true, SwiftCode: "DDDDPLPWXXX"}
+		created, err := client.Create(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, "DDDDPLPWXXX", created.GetSwiftCode())
+
+		_, err = client.Create(ctx, req)
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+		_, err = client.Delete(ctx, &swiftcodespb.DeleteRequest{SwiftCode: "DDDDPLPWXXX"})
+		require.NoError(t, err)
+		_, err = client.Delete(ctx, &swiftcodespb.DeleteRequest{SwiftCode: "DDDDPLPWXXX"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("TestRPCServer_untrustedActor", func(t *testing.T) {
+		actorCtx := metadata.AppendToOutgoingContext(ctx, "x-actor", "alice")
+		_, err := client.Create(actorCtx, &swiftcodespb.CreateRequest{Address: "NOWA 6", BankName: "EPSILON BANK", CountryIso2: "PL", CountryName: "POLAND", IsHeadquarter: true, SwiftCode: "EEEEPLPWXXX"})
+		require.NoError(t, err)
+
+		history, err := services.NewSwiftCodeService(repo).GetSwiftCodeHistory(ctx, "EEEEPLPWXXX")
+		require.NoError(t, err)
+		require.Len(t, history.Entries, 1)
+		assert.NotEqual(t, "alice", history.Entries[0].Actor)
+		assert.Equal(t, "alice", history.Entries[0].ClaimedActor)
+	})
+
+	t.Run("TestRPCServer_createInvalid", func(t *testing.T) {
+		_, err := client.Create(ctx, &swiftcodespb.CreateRequest{CountryIso2: "P", SwiftCode: "DDDDPLPWXXX"})
+		st := status.Convert(err)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+		require.Len(t, st.Details(), 1)
+		assert.Len(t, st.Details()[0].(*errdetails.BadRequest).GetFieldViolations(), 3)
+	})
+
+	t.Run("TestRPCServer_createCountryMismatch", func(t *testing.T) {
+		_, err := client.Create(ctx, &swiftcodespb.CreateRequest{Address: "NOWA 5", BankName: "DELTA BANK", CountryIso2: "PL", CountryName: "POLSKA", IsHeadquarter: true, SwiftCode: "DDDDPLPWXXX"})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
+
+// slowCountryService answers each page of a country after delay, or fails when
+// the context of the page is done first. The last of pages has no next cursor.
+type slowCountryService struct {
+	*MockSwiftCodeService
+	delay time.Duration
+	pages int
+}
+
+func (s *slowCountryService) GetSwiftCodesByCountry(ctx context.Context, iso2 string, query models.CountryQuery) (interface{}, error) {
+	select {
+	case <-time.After(s.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	page := 0
+	if query.After != nil {
+		page, _ = strconv.Atoi(query.After.SwiftCode)
+	}
+	country := models.SwiftCodeCountry{CountryISO2: iso2, SwiftCodes: []models.SwiftCodeBank{{SwiftCode: fmt.Sprintf("AAAAPLPW%03d", page)}}}
+	if page+1 < s.pages {
+		country.NextCursor = models.EncodeCursor(models.CountryCursor{SwiftCode: strconv.Itoa(page + 1)})
+	}
+	return country, nil
+}
+
+func TestRPCServerErrors(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("TestRPCServerErrors_internal", func(t *testing.T) {
+		mockService := new(MockSwiftCodeService)
+		mockService.On("DeleteSwiftCode", "AAAAPLPWXXX").Return(errors.New("connection refused"))
+		client := newRPCClient(t, mockService, rpc.Options{})
+
+		_, err := client.Delete(ctx, &swiftcodespb.DeleteRequest{SwiftCode: "AAAAPLPWXXX"})
+		st := status.Convert(err)
+		assert.Equal(t, codes.Internal, st.Code())
+		assert.NotContains(t, st.Message(), "connection refused")
+	})
+
+	t.Run("TestRPCServerErrors_timeoutPerPage", func(t *testing.T) {
+		service := &slowCountryService{MockSwiftCodeService: new(MockSwiftCodeService), delay: 40 * time.Millisecond, pages: 4}
+		client := newRPCClient(t, service, rpc.Options{RequestTimeout: 100 * time.Millisecond})
+
+		stream, err := client.ListByCountry(ctx, &swiftcodespb.ListByCountryRequest{CountryIso2: "PL"})
+		require.NoError(t, err)
+		received := 0
+		for {
+			_, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			received++
+		}
+		assert.Equal(t, 4, received)
+	})
+
+	t.Run("TestRPCServerErrors_timeout", func(t *testing.T) {
+		mockService := new(MockSwiftCodeService)
+		mockService.On("GetBranchDetails", "AAAAPLPWKRK").Return(nil, context.DeadlineExceeded).Run(func(args mock.Arguments) {
+			time.Sleep(10 * time.Millisecond)
+		})
+		client := newRPCClient(t, mockService, rpc.Options{RequestTimeout: time.Millisecond})
+
+		_, err := client.GetCode(ctx, &swiftcodespb.GetCodeRequest{SwiftCode: "AAAAPLPWKRK"})
+		assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	})
+}
@@ -3,10 +3,15 @@ package unitTests
 import (
 	"RemitlyTask/src/models"
 	"RemitlyTask/src/services"
+	"RemitlyTask/src/validation"
+	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestGetHeadquarterDetails(t *testing.T) {
@@ -32,7 +37,7 @@ func TestGetHeadquarterDetails(t *testing.T) {
 		}
 		mockRepo.On("FindBySwiftCodePrefix", "TESTUSAB").Return(swiftCodes, nil)
 
-		response, err := service.GetHeadquarterDetails("TESTUSAB")
+		response, err := service.GetHeadquarterDetails(context.Background(), "TESTUSAB")
 
 		assert.NoError(t, err)
 		assert.NotNil(t, response)
@@ -60,9 +65,9 @@ func TestGetHeadquarterDetails(t *testing.T) {
 		}
 		mockRepo.On("FindBySwiftCodePrefix", "TESTUSAB").Return(swiftCodes, nil)
 
-		response, err := service.GetHeadquarterDetails("TESTUSAB")
+		response, err := service.GetHeadquarterDetails(context.Background(), "TESTUSAB")
 
-		assert.NoError(t, err)
+		assert.ErrorIs(t, err, models.ErrNotFound)
 		assert.Nil(t, response)
 		mockRepo.AssertExpectations(t)
 	})
@@ -73,7 +78,7 @@ func TestGetHeadquarterDetails(t *testing.T) {
 
 		mockRepo.On("FindBySwiftCodePrefix", "TESTUSAB").Return([]models.SwiftCode{}, errors.New("repository error"))
 
-		response, err := service.GetHeadquarterDetails("TESTUSAB")
+		response, err := service.GetHeadquarterDetails(context.Background(), "TESTUSAB")
 
 		assert.Error(t, err)
 		assert.Nil(t, response)
@@ -95,7 +100,7 @@ func TestGetBranchDetails(t *testing.T) {
 		}
 		mockRepo.On("FindBySwiftCode", "TESTUSABNYC").Return(branch, nil)
 
-		response, err := service.GetBranchDetails("TESTUSABNYC")
+		response, err := service.GetBranchDetails(context.Background(), "TESTUSABNYC")
 
 		assert.NoError(t, err)
 		assert.NotNil(t, response)
@@ -113,9 +118,9 @@ func TestGetBranchDetails(t *testing.T) {
 
 		mockRepo.On("FindBySwiftCode", "TESTUSABNYC").Return(models.SwiftCode{}, nil)
 
-		response, err := service.GetBranchDetails("TESTUSABNYC")
+		response, err := service.GetBranchDetails(context.Background(), "TESTUSABNYC")
 
-		assert.NoError(t, err)
+		assert.ErrorIs(t, err, models.ErrNotFound)
 		assert.Nil(t, response)
 		mockRepo.AssertExpectations(t)
 	})
@@ -126,7 +131,7 @@ func TestGetBranchDetails(t *testing.T) {
 
 		mockRepo.On("FindBySwiftCode", "TESTUSABNYC").Return(models.SwiftCode{}, errors.New("Repository error"))
 
-		response, err := service.GetBranchDetails("TESTUSABNYC")
+		response, err := service.GetBranchDetails(context.Background(), "TESTUSABNYC")
 
 		assert.Error(t, err)
 		assert.Nil(t, response)
@@ -156,9 +161,9 @@ func TestGetSwiftCodesByCountry(t *testing.T) {
 			},
 		}
 		mockRepo.On("FindCountryNameByISO2", "US").Return("UNITED STATES", nil)
-		mockRepo.On("FindByCountryISO2", "US").Return(swiftCodes, nil)
+		mockRepo.On("FindByCountryISO2", "US", models.CountryQuery{}).Return(swiftCodes, nil)
 
-		response, err := service.GetSwiftCodesByCountry("US")
+		response, err := service.GetSwiftCodesByCountry(context.Background(), "US", models.CountryQuery{})
 
 		assert.NoError(t, err)
 		assert.NotNil(t, response)
@@ -168,14 +173,27 @@ func TestGetSwiftCodesByCountry(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 
+	t.Run("TestGetSwiftCodesByCountry_unknownCountry", func(t *testing.T) {
+		mockRepo := &MockSwiftCodeRepository{}
+		service := services.NewSwiftCodeService(mockRepo)
+
+		mockRepo.On("FindCountryNameByISO2", "XX").Return("", nil)
+
+		response, err := service.GetSwiftCodesByCountry(context.Background(), "XX", models.CountryQuery{})
+
+		assert.ErrorIs(t, err, models.ErrNotFound)
+		assert.Nil(t, response)
+		mockRepo.AssertNotCalled(t, "FindByCountryISO2", "XX", models.CountryQuery{})
+	})
+
 	t.Run("TestGetSwiftCodesByCountry_noCodesFoundForCountry", func(t *testing.T) {
 		mockRepo := &MockSwiftCodeRepository{}
 		service := services.NewSwiftCodeService(mockRepo)
 
 		mockRepo.On("FindCountryNameByISO2", "US").Return("UNITED STATES", nil)
-		mockRepo.On("FindByCountryISO2", "US").Return([]models.SwiftCode{}, nil)
+		mockRepo.On("FindByCountryISO2", "US", models.CountryQuery{}).Return([]models.SwiftCode{}, nil)
 
-		response, err := service.GetSwiftCodesByCountry("US")
+		response, err := service.GetSwiftCodesByCountry(context.Background(), "US", models.CountryQuery{})
 
 		assert.NoError(t, err)
 		assert.NotNil(t, response)
@@ -185,13 +203,51 @@ func TestGetSwiftCodesByCountry(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 
+	t.Run("TestGetSwiftCodesByCountry_nextPage", func(t *testing.T) {
+		mockRepo := &MockSwiftCodeRepository{}
+		service := services.NewSwiftCodeService(mockRepo)
+
+		swiftCodes := []models.SwiftCode{
+			{SwiftCode: "AAAAUSNYXXX", Name: "A BANK", CountryISO2: "US"},
+			{SwiftCode: "BBBBUSNYXXX", Name: "B BANK", CountryISO2: "US"},
+			{SwiftCode: "CCCCUSNYXXX", Name: "C BANK", CountryISO2: "US"},
+		}
+		mockRepo.On("FindCountryNameByISO2", "US").Return("UNITED STATES", nil)
+		mockRepo.On("FindByCountryISO2", "US", models.CountryQuery{Limit: 3, SortBy: models.SortByBankName}).Return(swiftCodes, nil)
+
+		response, err := service.GetSwiftCodesByCountry(context.Background(), "US", models.CountryQuery{Limit: 2, SortBy: models.SortByBankName})
+
+		assert.NoError(t, err)
+		country := response.(models.SwiftCodeCountry)
+		assert.Len(t, country.SwiftCodes, 2)
+		cursor, err := models.DecodeCursor(country.NextCursor)
+		assert.NoError(t, err)
+		assert.Equal(t, models.CountryCursor{SortBy: models.SortByBankName, Value: "B BANK", SwiftCode: "BBBBUSNYXXX"}, cursor)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("TestGetSwiftCodesByCountry_lastPage", func(t *testing.T) {
+		mockRepo := &MockSwiftCodeRepository{}
+		service := services.NewSwiftCodeService(mockRepo)
+
+		swiftCodes := []models.SwiftCode{{SwiftCode: "AAAAUSNYXXX", Name: "A BANK", CountryISO2: "US"}}
+		mockRepo.On("FindCountryNameByISO2", "US").Return("UNITED STATES", nil)
+		mockRepo.On("FindByCountryISO2", "US", models.CountryQuery{Limit: 3}).Return(swiftCodes, nil)
+
+		response, err := service.GetSwiftCodesByCountry(context.Background(), "US", models.CountryQuery{Limit: 2})
+
+		assert.NoError(t, err)
+		assert.Empty(t, response.(models.SwiftCodeCountry).NextCursor)
+		mockRepo.AssertExpectations(t)
+	})
+
 	t.Run("TestGetSwiftCodesByCountry_repositoryError", func(t *testing.T) {
 		mockRepo := &MockSwiftCodeRepository{}
 		service := services.NewSwiftCodeService(mockRepo)
 
 		mockRepo.On("FindCountryNameByISO2", "US").Return("", errors.New("repository error"))
 
-		response, err := service.GetSwiftCodesByCountry("US")
+		response, err := service.GetSwiftCodesByCountry(context.Background(), "US", models.CountryQuery{})
 
 		assert.Error(t, err)
 		assert.Nil(t, response)
@@ -213,7 +269,7 @@ func TestAddSwiftCode(t *testing.T) {
 		}
 		mockRepo.On("Create", newCode).Return(nil)
 
-		err := service.AddSwiftCode(newCode)
+		err := service.AddSwiftCode(context.Background(), newCode)
 
 		assert.NoError(t, err)
 		mockRepo.AssertExpectations(t)
@@ -232,21 +288,98 @@ func TestAddSwiftCode(t *testing.T) {
 		}
 		mockRepo.On("Create", newCode).Return(errors.New("repository error"))
 
-		err := service.AddSwiftCode(newCode)
+		err := service.AddSwiftCode(context.Background(), newCode)
+
+		assert.Error(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestAddSwiftCodes(t *testing.T) {
+	t.Run("TestAddSwiftCodes_successful", func(t *testing.T) {
+		mockRepo := &MockSwiftCodeRepository{}
+		service := services.NewSwiftCodeService(mockRepo)
+
+		newCodes := []*models.SwiftCode{
+			{SwiftCode: "TESTPLPWXXX", Name: "Test Bank HQ", CountryISO2: "pl", Address: "1 Test St", CountryName: "Poland"},
+			{SwiftCode: "TESTPLPWKRK", Name: "Test Bank", CountryISO2: "PL", Address: "2 Test St", CountryName: "POLAND"},
+		}
+		mockRepo.On("CreateBatch", newCodes).Return(nil)
+
+		err := service.AddSwiftCodes(context.Background(), newCodes)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "PL", newCodes[0].CountryISO2)
+		assert.Equal(t, "POLAND", newCodes[0].CountryName)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("TestAddSwiftCodes_repositoryError", func(t *testing.T) {
+		mockRepo := &MockSwiftCodeRepository{}
+		service := services.NewSwiftCodeService(mockRepo)
+
+		newCodes := []*models.SwiftCode{{SwiftCode: "TESTPLPWXXX", CountryISO2: "PL", Address: "1 Test St"}}
+		mockRepo.On("CreateBatch", newCodes).Return(errors.New("repository error"))
+
+		err := service.AddSwiftCodes(context.Background(), newCodes)
 
 		assert.Error(t, err)
 		mockRepo.AssertExpectations(t)
 	})
 }
 
+func TestValidateNewSwiftCode(t *testing.T) {
+	newCode := models.SwiftCodeBranch{Address: "NOWA 4", BankName: "GAMMA BANK", CountryISO2: "pl", CountryName: "Poland", IsHeadquarter: true, SwiftCode: "CCCCPLPWXXX"}
+
+	t.Run("TestValidateNewSwiftCode_valid", func(t *testing.T) {
+		mockRepo := &MockSwiftCodeRepository{}
+		service := services.NewSwiftCodeService(mockRepo)
+
+		mockRepo.On("FindCountryNameByISO2", "PL").Return("POLAND", nil)
+
+		code, err := service.ValidateNewSwiftCode(context.Background(), newCode)
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.SwiftCode{Address: "NOWA 4", Name: "GAMMA BANK", CountryISO2: "pl", CountryName: "Poland", SwiftCode: "CCCCPLPWXXX"}, code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("TestValidateNewSwiftCode_invalidFields", func(t *testing.T) {
+		mockRepo := &MockSwiftCodeRepository{}
+		service := services.NewSwiftCodeService(mockRepo)
+
+		invalid := newCode
+		invalid.Address = ""
+		invalid.IsHeadquarter = false
+
+		_, err := service.ValidateNewSwiftCode(context.Background(), invalid)
+
+		assert.ErrorIs(t, err, models.ErrValidation)
+		assert.ErrorIs(t, err, validation.ErrEmptyAddress)
+		assert.ErrorIs(t, err, validation.ErrHeadquarterMismatch)
+		mockRepo.AssertNotCalled(t, "FindCountryNameByISO2", mock.Anything)
+	})
+
+	t.Run("TestValidateNewSwiftCode_countryMismatch", func(t *testing.T) {
+		mockRepo := &MockSwiftCodeRepository{}
+		service := services.NewSwiftCodeService(mockRepo)
+
+		mockRepo.On("FindCountryNameByISO2", "PL").Return("POLSKA", nil)
+
+		_, err := service.ValidateNewSwiftCode(context.Background(), newCode)
+
+		assert.ErrorIs(t, err, models.ErrConflict)
+	})
+}
+
 func TestDeleteSwiftCode(t *testing.T) {
 	t.Run("TestDeleteSwiftCode_successful", func(t *testing.T) {
 		mockRepo := &MockSwiftCodeRepository{}
 		service := services.NewSwiftCodeService(mockRepo)
 
-		mockRepo.On("Delete", "TESTUSABXXX").Return(nil)
+		mockRepo.On("DeleteHeadquarter", "TESTUSABXXX", false).Return([]string{"TESTUSABXXX"}, nil)
 
-		err := service.DeleteSwiftCode("TESTUSABXXX")
+		err := service.DeleteSwiftCode(context.Background(), "TESTUSABXXX")
 
 		assert.NoError(t, err)
 		mockRepo.AssertExpectations(t)
@@ -256,11 +389,313 @@ func TestDeleteSwiftCode(t *testing.T) {
 		mockRepo := &MockSwiftCodeRepository{}
 		service := services.NewSwiftCodeService(mockRepo)
 
-		mockRepo.On("Delete", "TESTUSABXXX").Return(errors.New("Repository error"))
+		mockRepo.On("DeleteHeadquarter", "TESTUSABXXX", false).Return(nil, errors.New("Repository error"))
+
+		err := service.DeleteSwiftCode(context.Background(), "TESTUSABXXX")
+
+		assert.Error(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("TestDeleteSwiftCode_headquarterWithBranches", func(t *testing.T) {
+		mockRepo := &MockSwiftCodeRepository{}
+		service := services.NewSwiftCodeService(mockRepo)
+
+		mockRepo.On("DeleteHeadquarter", "TESTUSABXXX", false).Return(nil, fmt.Errorf("SWIFT code TESTUSABXXX has 1 branches: %w", models.ErrConflict))
+
+		err := service.DeleteSwiftCode(context.Background(), "TESTUSABXXX")
+
+		assert.ErrorIs(t, err, models.ErrConflict)
+		mockRepo.AssertNotCalled(t, "Delete", mock.Anything)
+	})
+
+	t.Run("TestDeleteSwiftCode_branch", func(t *testing.T) {
+		mockRepo := &MockSwiftCodeRepository{}
+		service := services.NewSwiftCodeService(mockRepo)
+
+		mockRepo.On("Delete", "TESTUSAB123").Return(nil)
+
+		err := service.DeleteSwiftCode(context.Background(), "TESTUSAB123")
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestDeleteSwiftCodeCascade(t *testing.T) {
+	t.Run("TestDeleteSwiftCodeCascade_headquarter", func(t *testing.T) {
+		mockRepo := &MockSwiftCodeRepository{}
+		service := services.NewSwiftCodeService(mockRepo)
+
+		mockRepo.On("DeleteHeadquarter", "TESTUSABXXX", true).Return([]string{"TESTUSAB001", "TESTUSAB123", "TESTUSABXXX"}, nil)
+
+		removed, err := service.DeleteSwiftCodeCascade(context.Background(), "TESTUSABXXX")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"TESTUSAB001", "TESTUSAB123", "TESTUSABXXX"}, removed)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("TestDeleteSwiftCodeCascade_headquarterNotFound", func(t *testing.T) {
+		mockRepo := &MockSwiftCodeRepository{}
+		service := services.NewSwiftCodeService(mockRepo)
+
+		mockRepo.On("DeleteHeadquarter", "TESTUSABXXX", true).Return(nil, fmt.Errorf("SWIFT code TESTUSABXXX %w", models.ErrNotFound))
+
+		_, err := service.DeleteSwiftCodeCascade(context.Background(), "TESTUSABXXX")
+
+		assert.ErrorIs(t, err, models.ErrNotFound)
+	})
+
+	t.Run("TestDeleteSwiftCodeCascade_branch", func(t *testing.T) {
+		mockRepo := &MockSwiftCodeRepository{}
+		service := services.NewSwiftCodeService(mockRepo)
+
+		mockRepo.On("Delete", "TESTUSAB123").Return(nil)
 
-		err := service.DeleteSwiftCode("TESTUSABXXX")
+		removed, err := service.DeleteSwiftCodeCascade(context.Background(), "TESTUSAB123")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"TESTUSAB123"}, removed)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestRestoreSwiftCode(t *testing.T) {
+	t.Run("TestRestoreSwiftCode_successful", func(t *testing.T) {
+		mockRepo := &MockSwiftCodeRepository{}
+		service := services.NewSwiftCodeService(mockRepo)
+
+		mockRepo.On("Restore", "TESTUSABXXX").Return(nil)
+		mockRepo.On("FindBySwiftCode", "TESTUSABXXX").Return(models.SwiftCode{SwiftCode: "TESTUSABXXX", Name: "TEST BANK"}, nil)
+
+		err := service.RestoreSwiftCode(context.Background(), "TESTUSABXXX")
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("TestRestoreSwiftCode_notDeleted", func(t *testing.T) {
+		mockRepo := &MockSwiftCodeRepository{}
+		service := services.NewSwiftCodeService(mockRepo)
+
+		mockRepo.On("Restore", "TESTUSABXXX").Return(models.ErrConflict)
+
+		err := service.RestoreSwiftCode(context.Background(), "TESTUSABXXX")
+
+		assert.ErrorIs(t, err, models.ErrConflict)
+		mockRepo.AssertNotCalled(t, "FindBySwiftCode", "TESTUSABXXX")
+	})
+}
+
+func TestPurgeDeletedSwiftCodes(t *testing.T) {
+	mockRepo := &MockSwiftCodeRepository{}
+	service := services.NewSwiftCodeService(mockRepo)
+
+	mockRepo.On("Purge", mock.MatchedBy(func(deletedBefore time.Time) bool {
+		cutoff := time.Now().Add(-24 * time.Hour)
+		return deletedBefore.After(cutoff.Add(-time.Minute)) && deletedBefore.Before(cutoff.Add(time.Minute))
+	})).Return(3, nil)
+
+	purged, err := service.PurgeDeletedSwiftCodes(context.Background(), 24*time.Hour)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, purged)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateSwiftCode(t *testing.T) {
+	stored := models.SwiftCode{
+		SwiftCode:   "TESTPLPWXXX",
+		Name:        "Test Bank HQ",
+		CountryISO2: "PL",
+		Address:     "123 Test St",
+		TownName:    "WARSZAWA",
+		CountryName: "POLAND",
+		TimeZone:    "Europe/Warsaw",
+	}
+
+	t.Run("TestUpdateSwiftCode_successful", func(t *testing.T) {
+		mockRepo := &MockSwiftCodeRepository{}
+		service := services.NewSwiftCodeService(mockRepo)
+
+		address, empty := "456 New St", ""
+		expected := stored
+		expected.Address = address
+		expected.TimeZone = empty
+
+		mockRepo.On("FindBySwiftCode", "TESTPLPWXXX").Return(stored, nil)
+		mockRepo.On("Update", &expected).Return(nil)
+
+		err := service.UpdateSwiftCode(context.Background(), "TESTPLPWXXX", models.SwiftCodePatch{Address: &address, TimeZone: &empty})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("TestUpdateSwiftCode_notFound", func(t *testing.T) {
+		mockRepo := &MockSwiftCodeRepository{}
+		service := services.NewSwiftCodeService(mockRepo)
+
+		mockRepo.On("FindBySwiftCode", "TESTPLPWXXX").Return(models.SwiftCode{}, nil)
+
+		err := service.UpdateSwiftCode(context.Background(), "TESTPLPWXXX", models.SwiftCodePatch{})
+
+		assert.ErrorIs(t, err, models.ErrNotFound)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("TestUpdateSwiftCode_immutableFields", func(t *testing.T) {
+		mockRepo := &MockSwiftCodeRepository{}
+		service := services.NewSwiftCodeService(mockRepo)
+
+		mockRepo.On("FindBySwiftCode", "TESTPLPWXXX").Return(stored, nil)
+
+		otherCode, otherCountry, sameCountry := "TESTPLPWABC", "DE", "pl"
+		err := service.UpdateSwiftCode(context.Background(), "TESTPLPWXXX", models.SwiftCodePatch{SwiftCode: &otherCode})
+		assert.ErrorIs(t, err, validation.ErrImmutable)
+
+		err = service.UpdateSwiftCode(context.Background(), "TESTPLPWXXX", models.SwiftCodePatch{CountryISO2: &otherCountry})
+		assert.ErrorIs(t, err, validation.ErrImmutable)
+
+		mockRepo.On("Update", mock.AnythingOfType("*models.SwiftCode")).Return(nil)
+		err = service.UpdateSwiftCode(context.Background(), "TESTPLPWXXX", models.SwiftCodePatch{CountryISO2: &sameCountry})
+		assert.NoError(t, err)
+	})
+
+	t.Run("TestUpdateSwiftCode_emptyBankName", func(t *testing.T) {
+		mockRepo := &MockSwiftCodeRepository{}
+		service := services.NewSwiftCodeService(mockRepo)
+
+		mockRepo.On("FindBySwiftCode", "TESTPLPWXXX").Return(stored, nil)
+
+		empty := ""
+		err := service.UpdateSwiftCode(context.Background(), "TESTPLPWXXX", models.SwiftCodePatch{BankName: &empty})
+
+		assert.ErrorIs(t, err, validation.ErrEmptyBankName)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+}
+
+func TestSearchSwiftCodes(t *testing.T) {
+	t.Run("TestSearchSwiftCodes_successful", func(t *testing.T) {
+		mockRepo := &MockSwiftCodeRepository{}
+		service := services.NewSwiftCodeService(mockRepo)
+
+		scored := []models.ScoredSwiftCode{
+			{Code: models.SwiftCode{SwiftCode: "ALBPPLPWXXX", Name: "ALIOR BANK SPOLKA AKCYJNA", CountryISO2: "PL", Address: "LOPUSZANSKA 38 D"}, Score: 1},
+			{Code: models.SwiftCode{SwiftCode: "ALBPPLPWCUS", Name: "ALIOR BANK SPOLKA AKCYJNA", CountryISO2: "PL", Address: "LOPUSZANSKA 38 D"}, Score: 0.8},
+		}
+		mockRepo.On("Search", "alior", "PL", 10).Return(scored, nil)
+
+		results, err := service.SearchSwiftCodes(context.Background(), "alior", "PL", 10)
+
+		assert.NoError(t, err)
+		assert.Len(t, results, 2)
+		assert.True(t, results[0].IsHeadquarter)
+		assert.False(t, results[1].IsHeadquarter)
+		assert.Equal(t, 0.8, results[1].Score)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("TestSearchSwiftCodes_noResults", func(t *testing.T) {
+		mockRepo := &MockSwiftCodeRepository{}
+		service := services.NewSwiftCodeService(mockRepo)
+
+		mockRepo.On("Search", "nothing", "", 10).Return([]models.ScoredSwiftCode{}, nil)
+
+		results, err := service.SearchSwiftCodes(context.Background(), "nothing", "", 10)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, results)
+		assert.Empty(t, results)
+	})
+}
+
+func TestSuggestSwiftCodes(t *testing.T) {
+	t.Run("TestSuggestSwiftCodes_indexFollowsWrites", func(t *testing.T) {
+		mockRepo := &MockSwiftCodeRepository{}
+		service := services.NewSwiftCodeService(mockRepo)
+
+		mockRepo.On("FindAll").Return([]models.SwiftCode{
+			{SwiftCode: "ALBPPLPWXXX", Name: "ALIOR BANK SPOLKA AKCYJNA", CountryISO2: "PL"},
+		}, nil).Once()
+		mockRepo.On("Create", mock.AnythingOfType("*models.SwiftCode")).Return(nil)
+		mockRepo.On("DeleteHeadquarter", "ALBPPLPWXXX", false).Return([]string{"ALBPPLPWXXX"}, nil)
+
+		suggestions, err := service.SuggestSwiftCodes(context.Background(), "ALBP", 10)
+		assert.NoError(t, err)
+		assert.Len(t, suggestions, 1)
+
+		err = service.AddSwiftCode(context.Background(), &models.SwiftCode{SwiftCode: "ALBPPLPWCUS", Name: "ALIOR BANK SPOLKA AKCYJNA", CountryISO2: "pl"})
+		assert.NoError(t, err)
+		err = service.DeleteSwiftCode(context.Background(), "ALBPPLPWXXX")
+		assert.NoError(t, err)
+
+		suggestions, err = service.SuggestSwiftCodes(context.Background(), "ALBP", 10)
+		assert.NoError(t, err)
+		assert.Equal(t, []models.SwiftCodeSuggestion{{SwiftCode: "ALBPPLPWCUS", BankName: "ALIOR BANK SPOLKA AKCYJNA", CountryISO2: "PL"}}, suggestions)
+		mockRepo.AssertNumberOfCalls(t, "FindAll", 1)
+	})
+
+	t.Run("TestSuggestSwiftCodes_repositoryError", func(t *testing.T) {
+		mockRepo := &MockSwiftCodeRepository{}
+		service := services.NewSwiftCodeService(mockRepo)
+
+		mockRepo.On("FindAll").Return([]models.SwiftCode{}, errors.New("repository error"))
+
+		_, err := service.SuggestSwiftCodes(context.Background(), "ALBP", 10)
 
 		assert.Error(t, err)
+	})
+}
+
+func TestLookupSwiftCodes(t *testing.T) {
+	t.Run("TestLookupSwiftCodes_mixedResults", func(t *testing.T) {
+		mockRepo := &MockSwiftCodeRepository{}
+		service := services.NewSwiftCodeService(mockRepo)
+
+		stored := []models.SwiftCode{
+			{SwiftCode: "ALBPPLPWCUS", Name: "ALIOR BANK", CountryISO2: "PL", CountryName: "POLAND", Address: "BRANCH ST"},
+			{SwiftCode: "ALBPPLPWXXX", Name: "ALIOR BANK", CountryISO2: "PL", CountryName: "POLAND", Address: "HQ ST"},
+			{SwiftCode: "BPKOPLPWABC", Name: "PKO BANK POLSKI", CountryISO2: "PL", CountryName: "POLAND", Address: "PKO ST"},
+		}
+		mockRepo.On("FindByBankCodes", []string{"ALBPPLPW", "BPKOPLPW", "MISSPLPW"}).Return(stored, nil).Once()
+
+		results, err := service.LookupSwiftCodes(context.Background(), []string{"ALBPPLPWXXX", "BPKOPLPWABC", "bad", "ALBPPLPWCUS", "MISSPLPWXXX", "BPKOPLPWXXX"})
+
+		assert.NoError(t, err)
+		assert.Len(t, results, 6)
+
+		assert.True(t, results[0].Found)
+		details := results[0].Details.(models.SwiftCodeDetails)
+		assert.Equal(t, "HQ ST", details.Address)
+		assert.Len(t, details.Branches, 1)
+
+		assert.True(t, results[1].Found)
+		assert.Equal(t, "PKO ST", results[1].Details.(models.SwiftCodeBranch).Address)
+
+		assert.False(t, results[2].Found)
+		assert.Equal(t, models.LookupErrorInvalidFormat, results[2].Error.Code)
+
+		assert.True(t, results[3].Found)
+		assert.Equal(t, "ALBPPLPWCUS", results[3].Details.(models.SwiftCodeBranch).SwiftCode)
+
+		assert.Equal(t, models.LookupErrorNotFound, results[4].Error.Code)
+		assert.Equal(t, "SWIFT code MISSPLPWXXX not found", results[4].Error.Message)
+		assert.Equal(t, models.LookupErrorNotFound, results[5].Error.Code)
 		mockRepo.AssertExpectations(t)
 	})
+
+	t.Run("TestLookupSwiftCodes_repositoryError", func(t *testing.T) {
+		mockRepo := &MockSwiftCodeRepository{}
+		service := services.NewSwiftCodeService(mockRepo)
+
+		mockRepo.On("FindByBankCodes", []string{"ALBPPLPW"}).Return([]models.SwiftCode{}, errors.New("repository error"))
+
+		results, err := service.LookupSwiftCodes(context.Background(), []string{"ALBPPLPWXXX"})
+
+		assert.Error(t, err)
+		assert.Nil(t, results)
+	})
 }
@@ -0,0 +1,64 @@
+package unitTests
+
+import (
+	"RemitlyTask/src/models"
+	"RemitlyTask/src/suggest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func suggestionCodes(suggestions []models.SwiftCodeSuggestion) []string {
+	codes := []string{}
+	for _, suggestion := range suggestions {
+		codes = append(codes, suggestion.SwiftCode)
+	}
+	return codes
+}
+
+func TestSuggestIndex(t *testing.T) {
+	codes := []models.SwiftCode{
+		{SwiftCode: "ALBPPLPWCUS", Name: "ALIOR BANK SPOLKA AKCYJNA", CountryISO2: "PL"},
+		{SwiftCode: "ALBPPLPWXXX", Name: "ALIOR BANK SPOLKA AKCYJNA", CountryISO2: "PL"},
+		{SwiftCode: "ALBPPLP1BMW", Name: "ALIOR BANK SPOLKA AKCYJNA", CountryISO2: "PL"},
+		{SwiftCode: "UNCRITMMXXX", Name: "UniCredit S.p.A.", CountryISO2: "IT"},
+		{SwiftCode: "BPKOPLPWXXX", Name: "PKO BANK POLSKI", CountryISO2: "PL"},
+	}
+
+	t.Run("TestSuggestIndex_swiftCodePrefix", func(t *testing.T) {
+		index := suggest.NewIndex(codes)
+
+		assert.Equal(t, []string{"ALBPPLP1BMW", "ALBPPLPWCUS", "ALBPPLPWXXX"}, suggestionCodes(index.Suggest("albp", 10)))
+		assert.Equal(t, []string{"ALBPPLP1BMW", "ALBPPLPWCUS"}, suggestionCodes(index.Suggest("ALBP", 2)))
+	})
+
+	t.Run("TestSuggestIndex_bankNamePrefixIsDeduplicated", func(t *testing.T) {
+		index := suggest.NewIndex(codes)
+
+		suggestions := index.Suggest("alior b", 10)
+
+		assert.Equal(t, []models.SwiftCodeSuggestion{{SwiftCode: "ALBPPLPWXXX", BankName: "ALIOR BANK SPOLKA AKCYJNA", CountryISO2: "PL"}}, suggestions)
+	})
+
+	t.Run("TestSuggestIndex_matchesAnyWordOfTheName", func(t *testing.T) {
+		index := suggest.NewIndex(codes)
+
+		assert.Equal(t, []string{"BPKOPLPWXXX", "ALBPPLPWXXX"}, suggestionCodes(index.Suggest("bank", 10)))
+		assert.Equal(t, []string{"UNCRITMMXXX"}, suggestionCodes(index.Suggest("unicr", 10)))
+	})
+
+	t.Run("TestSuggestIndex_writes", func(t *testing.T) {
+		index := suggest.NewIndex(codes)
+
+		index.Add(models.SwiftCode{SwiftCode: "BREXPLPWXXX", Name: "MBANK S.A.", CountryISO2: "PL"})
+		assert.Equal(t, []string{"BREXPLPWXXX"}, suggestionCodes(index.Suggest("mban", 10)))
+
+		index.Add(models.SwiftCode{SwiftCode: "BREXPLPWXXX", Name: "COMMERZBANK", CountryISO2: "PL"})
+		assert.Empty(t, index.Suggest("mban", 10))
+		assert.Equal(t, []string{"BREXPLPWXXX"}, suggestionCodes(index.Suggest("comm", 10)))
+
+		index.Remove("BREXPLPWXXX")
+		assert.Empty(t, index.Suggest("comm", 10))
+		assert.Empty(t, index.Suggest("brex", 10))
+	})
+}
@@ -0,0 +1,61 @@
+package unitTests
+
+import (
+	"RemitlyTask/src/validation"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBIC(t *testing.T) {
+	t.Run("TestParseBIC_headquarter", func(t *testing.T) {
+		bic, err := validation.ParseBIC("ALBPPLPWXXX")
+
+		assert.NoError(t, err)
+		assert.Equal(t, validation.BIC{InstitutionCode: "ALBP", CountryCode: "PL", LocationCode: "PW", BranchCode: "XXX"}, bic)
+		assert.True(t, bic.IsHeadquarter())
+		assert.Equal(t, "ALBPPLPWXXX", bic.String())
+	})
+
+	t.Run("TestParseBIC_eightCharacters", func(t *testing.T) {
+		bic, err := validation.ParseBIC("DEUTDEFF")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "", bic.BranchCode)
+		assert.False(t, bic.IsHeadquarter())
+	})
+
+	testCases := []struct {
+		name  string
+		input string
+		field string
+		err   error
+	}{
+		{name: "TestParseBIC_invalidLength", input: "ALBPPLP", field: validation.FieldSwiftCode, err: validation.ErrInvalidLength},
+		{name: "TestParseBIC_digitsOnly", input: "12345678", field: validation.FieldInstitutionCode, err: validation.ErrInvalidInstitutionCode},
+		{name: "TestParseBIC_lowercase", input: "albpplpwxxx", field: validation.FieldInstitutionCode, err: validation.ErrInvalidInstitutionCode},
+		{name: "TestParseBIC_invalidCountryCode", input: "ALBP1LPWXXX", field: validation.FieldCountryCode, err: validation.ErrInvalidCountryCode},
+		{name: "TestParseBIC_invalidLocationCode", input: "ALBPPLP-XXX", field: validation.FieldLocationCode, err: validation.ErrInvalidLocationCode},
+		{name: "TestParseBIC_invalidBranchCode", input: "ALBPPLPWxx1", field: validation.FieldBranchCode, err: validation.ErrInvalidBranchCode},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := validation.ParseBIC(tc.input)
+
+			var fieldErr *validation.FieldError
+			assert.True(t, errors.As(err, &fieldErr))
+			assert.Equal(t, tc.field, fieldErr.Field)
+			assert.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestValidateCountry(t *testing.T) {
+	bic, err := validation.ParseBIC("ALBPPLPWXXX")
+	assert.NoError(t, err)
+
+	assert.NoError(t, bic.ValidateCountry("PL"))
+	assert.ErrorIs(t, bic.ValidateCountry("DE"), validation.ErrCountryMismatch)
+}